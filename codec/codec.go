@@ -0,0 +1,470 @@
+// Package codec converts between the payload of KNX group telegrams and Go values
+// based on the datapoint type of the group address.
+//
+// The payload of datapoint types with up to 6 bits (e.g. DPT 1.x and 3.x) is a single byte
+// containing the value in its lowest bits. The payload of all other datapoint types
+// contains the value in big endian byte order.
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/brutella/ets-go/ets"
+)
+
+// Decode returns the value of the payload data of the datapoint type dpt.
+//
+// The values are of the following types:
+//
+//	1.x              bool
+//	3.x              Control3Bit
+//	5.001, 5.003     float64 (percent, degrees)
+//	5.x, 17.x, 20.x  uint8
+//	6.x              int8
+//	7.x              uint16
+//	8.x              int16
+//	9.x, 14.x        float64
+//	10.x             TimeOfDay
+//	11.x             Date
+//	12.x             uint32
+//	13.x             int32
+//	16.x             string
+//	18.x             SceneControl
+//	19.x             DateTime
+//	232.600          RGB
+func Decode(dpt ets.DatapointType, data []byte) (interface{}, error) {
+	size, ok := payloadSize(dpt)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported datapoint type %s", dpt)
+	}
+
+	if len(data) != size {
+		return nil, fmt.Errorf("Invalid payload length %d for datapoint type %s", len(data), dpt)
+	}
+
+	switch dpt.Main {
+	case 1:
+		return data[0]&0x01 == 0x01, nil
+	case 3:
+		return Control3Bit{Increase: data[0]&0x08 == 0x08, StepCode: data[0] & 0x07}, nil
+	case 5:
+		switch dpt.Sub {
+		case 1:
+			return float64(data[0]) * 100 / 255, nil
+		case 3:
+			return float64(data[0]) * 360 / 255, nil
+		default:
+			return data[0], nil
+		}
+	case 6:
+		return int8(data[0]), nil
+	case 7:
+		return binary.BigEndian.Uint16(data), nil
+	case 8:
+		return int16(binary.BigEndian.Uint16(data)), nil
+	case 9:
+		return decodeFloat16(binary.BigEndian.Uint16(data)), nil
+	case 10:
+		return TimeOfDay{
+			Weekday: data[0] >> 5,
+			Hour:    data[0] & 0x1F,
+			Minute:  data[1] & 0x3F,
+			Second:  data[2] & 0x3F,
+		}, nil
+	case 11:
+		return Date{
+			Year:  decodeYear(data[2] & 0x7F),
+			Month: time.Month(data[1] & 0x0F),
+			Day:   data[0] & 0x1F,
+		}, nil
+	case 12:
+		return binary.BigEndian.Uint32(data), nil
+	case 13:
+		return int32(binary.BigEndian.Uint32(data)), nil
+	case 14:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+	case 16:
+		return decodeString(data, dpt.Sub == 1), nil
+	case 17:
+		return data[0] & 0x3F, nil
+	case 18:
+		return SceneControl{Learn: data[0]&0x80 == 0x80, Scene: data[0] & 0x3F}, nil
+	case 19:
+		return DateTime{
+			Date: Date{
+				Year:  1900 + int(data[0]),
+				Month: time.Month(data[1] & 0x0F),
+				Day:   data[2] & 0x1F,
+			},
+			TimeOfDay: TimeOfDay{
+				Weekday: data[3] >> 5,
+				Hour:    data[3] & 0x1F,
+				Minute:  data[4] & 0x3F,
+				Second:  data[5] & 0x3F,
+			},
+			Fault:        data[6]&0x80 == 0x80,
+			WorkingDay:   data[6]&0x40 == 0x40,
+			SummerTime:   data[6]&0x01 == 0x01,
+			ExternalSync: data[7]&0x40 == 0x40,
+		}, nil
+	case 20:
+		return data[0], nil
+	case 232:
+		return RGB{R: data[0], G: data[1], B: data[2]}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported datapoint type %s", dpt)
+}
+
+// Encode returns the payload for the value v of the datapoint type dpt.
+// The value must be of the type returned by Decode or – for numeric datapoint types –
+// of any integer or floating point type.
+func Encode(dpt ets.DatapointType, v interface{}) ([]byte, error) {
+	if _, ok := payloadSize(dpt); !ok {
+		return nil, fmt.Errorf("Unsupported datapoint type %s", dpt)
+	}
+
+	switch dpt.Main {
+	case 1:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		if b {
+			return []byte{0x01}, nil
+		}
+		return []byte{0x00}, nil
+	case 3:
+		c, ok := v.(Control3Bit)
+		if !ok || c.StepCode > 7 {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := c.StepCode
+		if c.Increase {
+			b |= 0x08
+		}
+		return []byte{b}, nil
+	case 5:
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		switch dpt.Sub {
+		case 1:
+			f = f * 255 / 100
+		case 3:
+			f = f * 255 / 360
+		}
+		n, ok := toRange(math.Round(f), 0, math.MaxUint8)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		return []byte{uint8(n)}, nil
+	case 6, 17, 20:
+		min, max := 0.0, float64(math.MaxUint8)
+		switch dpt.Main {
+		case 6:
+			min, max = math.MinInt8, math.MaxInt8
+		case 17:
+			max = 63
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		n, ok := toRange(f, min, max)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		return []byte{uint8(int8(n))}, nil
+	case 7, 8:
+		min, max := 0.0, float64(math.MaxUint16)
+		if dpt.Main == 8 {
+			min, max = math.MinInt16, math.MaxInt16
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		n, ok := toRange(f, min, max)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, uint16(n))
+		return b, nil
+	case 9:
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		raw, ok := encodeFloat16(f)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, raw)
+		return b, nil
+	case 10:
+		t, ok := v.(TimeOfDay)
+		if !ok || t.Weekday > 7 || t.Hour > 23 || t.Minute > 59 || t.Second > 59 {
+			return nil, invalidValueError(dpt, v)
+		}
+		return []byte{t.Weekday<<5 | t.Hour, t.Minute, t.Second}, nil
+	case 11:
+		d, ok := v.(Date)
+		if !ok || d.Year < 1990 || d.Year > 2089 || d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 31 {
+			return nil, invalidValueError(dpt, v)
+		}
+		return []byte{d.Day, uint8(d.Month), uint8(d.Year % 100)}, nil
+	case 12, 13:
+		min, max := 0.0, float64(math.MaxUint32)
+		if dpt.Main == 13 {
+			min, max = math.MinInt32, math.MaxInt32
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		n, ok := toRange(f, min, max)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, uint32(n))
+		return b, nil
+	case 14:
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(f)))
+		return b, nil
+	case 16:
+		s, ok := v.(string)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		b, ok := encodeString(s, dpt.Sub == 1)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		return b, nil
+	case 18:
+		c, ok := v.(SceneControl)
+		if !ok || c.Scene > 63 {
+			return nil, invalidValueError(dpt, v)
+		}
+		b := c.Scene
+		if c.Learn {
+			b |= 0x80
+		}
+		return []byte{b}, nil
+	case 19:
+		dt, ok := v.(DateTime)
+		if !ok || dt.Year < 1900 || dt.Year > 2155 || dt.Month < 1 || dt.Month > 12 || dt.Date.Day < 1 || dt.Date.Day > 31 ||
+			dt.Weekday > 7 || dt.Hour > 24 || dt.Minute > 59 || dt.Second > 59 {
+			return nil, invalidValueError(dpt, v)
+		}
+		var flags0, flags1 byte
+		if dt.Fault {
+			flags0 |= 0x80
+		}
+		if dt.WorkingDay {
+			flags0 |= 0x40
+		}
+		if dt.SummerTime {
+			flags0 |= 0x01
+		}
+		if dt.ExternalSync {
+			flags1 |= 0x40
+		}
+		return []byte{
+			uint8(dt.Year - 1900),
+			uint8(dt.Month),
+			dt.Date.Day,
+			dt.Weekday<<5 | dt.Hour,
+			dt.Minute,
+			dt.Second,
+			flags0,
+			flags1,
+		}, nil
+	case 232:
+		c, ok := v.(RGB)
+		if !ok {
+			return nil, invalidValueError(dpt, v)
+		}
+		return []byte{c.R, c.G, c.B}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported datapoint type %s", dpt)
+}
+
+// DecodeGroupAddress returns the value of the payload data sent to the group address.
+// The first supported datapoint type of the group address is used.
+func DecodeGroupAddress(ga ets.GroupAddress, data []byte) (interface{}, error) {
+	dpts, err := ga.DatapointTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dpt := range dpts {
+		if IsSupported(dpt) {
+			return Decode(dpt, data)
+		}
+	}
+
+	return nil, fmt.Errorf("Group address %s has no supported datapoint type", ga.ID)
+}
+
+// IsSupported returns true if values of the datapoint type can be decoded and encoded.
+func IsSupported(dpt ets.DatapointType) bool {
+	_, ok := payloadSize(dpt)
+	return ok
+}
+
+// payloadSize returns the length of the payload of the datapoint type.
+func payloadSize(dpt ets.DatapointType) (int, bool) {
+	switch dpt.Main {
+	case 1, 3, 5, 6, 17, 18, 20:
+		return 1, true
+	case 7, 8, 9:
+		return 2, true
+	case 10, 11:
+		return 3, true
+	case 12, 13, 14:
+		return 4, true
+	case 16:
+		return 14, true
+	case 19:
+		return 8, true
+	case 232:
+		// Only RGB is supported.
+		return 3, dpt.Sub == 600 || !dpt.HasSubtype()
+	}
+
+	return 0, false
+}
+
+func invalidValueError(dpt ets.DatapointType, v interface{}) error {
+	return fmt.Errorf("Invalid value %v for datapoint type %s", v, dpt)
+}
+
+// decodeFloat16 decodes the KNX 2-byte float value 0.01*M*2^E.
+func decodeFloat16(raw uint16) float64 {
+	m := int(raw & 0x07FF)
+	if raw&0x8000 != 0 {
+		m -= 0x0800
+	}
+	e := uint(raw >> 11 & 0x0F)
+
+	return 0.01 * float64(m<<e)
+}
+
+// encodeFloat16 encodes f as KNX 2-byte float value.
+func encodeFloat16(f float64) (uint16, bool) {
+	v := math.Round(f * 100)
+	for e := uint(0); e <= 15; e++ {
+		m := math.Round(v / float64(int(1)<<e))
+		if m >= -2048 && m <= 2047 {
+			raw := uint16(e) << 11
+			if m < 0 {
+				raw |= 0x8000 | uint16(int(m)+0x0800)&0x07FF
+			} else {
+				raw |= uint16(m)
+			}
+			return raw, true
+		}
+	}
+
+	return 0, false
+}
+
+// decodeYear returns the year of a DPT 11.001 date.
+// Values >= 90 are interpreted as 20th century.
+func decodeYear(y uint8) int {
+	if y >= 90 {
+		return 1900 + int(y)
+	}
+
+	return 2000 + int(y)
+}
+
+// decodeString decodes a ASCII or ISO-8859-1 string padded with zero bytes.
+func decodeString(data []byte, latin1 bool) string {
+	var b strings.Builder
+	for _, c := range data {
+		if c == 0 {
+			break
+		}
+
+		if latin1 {
+			b.WriteRune(rune(c))
+		} else {
+			b.WriteByte(c & 0x7F)
+		}
+	}
+
+	return b.String()
+}
+
+// encodeString encodes s as ASCII or ISO-8859-1 string padded with zero bytes.
+func encodeString(s string, latin1 bool) ([]byte, bool) {
+	b := make([]byte, 14)
+	i := 0
+	for _, r := range s {
+		if i >= len(b) || (latin1 && r > 0xFF) || (!latin1 && r > 0x7F) {
+			return nil, false
+		}
+		b[i] = byte(r)
+		i++
+	}
+
+	return b, true
+}
+
+// toFloat converts the numeric value v to float64.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0, false
+}
+
+// toRange returns f as integer if it lies within [min, max].
+func toRange(f, min, max float64) (int64, bool) {
+	if math.IsNaN(f) || f < min || f > max || f != math.Trunc(f) {
+		return 0, false
+	}
+
+	return int64(f), true
+}
@@ -0,0 +1,95 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/brutella/ets-go/ets"
+)
+
+func TestCodec(t *testing.T) {
+	tests := []struct {
+		dpt   ets.DatapointType
+		data  []byte
+		value interface{}
+	}{
+		{ets.DatapointType{Main: 1, Sub: 1}, []byte{0x01}, true},
+		{ets.DatapointType{Main: 1, Sub: 1}, []byte{0x00}, false},
+		{ets.DatapointType{Main: 3, Sub: 7}, []byte{0x0B}, Control3Bit{Increase: true, StepCode: 3}},
+		{ets.DatapointType{Main: 5, Sub: 1}, []byte{0xFF}, 100.0},
+		{ets.DatapointType{Main: 5, Sub: 10}, []byte{0x2A}, uint8(42)},
+		{ets.DatapointType{Main: 6, Sub: 1}, []byte{0xFB}, int8(-5)},
+		{ets.DatapointType{Main: 7, Sub: 1}, []byte{0x12, 0x34}, uint16(0x1234)},
+		{ets.DatapointType{Main: 8, Sub: 1}, []byte{0xFF, 0xFE}, int16(-2)},
+		{ets.DatapointType{Main: 9, Sub: 1}, []byte{0x0C, 0x1A}, 21.0},
+		{ets.DatapointType{Main: 9, Sub: 1}, []byte{0x87, 0x9C}, -1.0},
+		{ets.DatapointType{Main: 10, Sub: 1}, []byte{0x2C, 0x1E, 0x05}, TimeOfDay{Weekday: 1, Hour: 12, Minute: 30, Second: 5}},
+		{ets.DatapointType{Main: 11, Sub: 1}, []byte{0x17, 0x0A, 0x1A}, Date{Year: 2026, Month: time.October, Day: 23}},
+		{ets.DatapointType{Main: 12, Sub: 1}, []byte{0x00, 0x01, 0x00, 0x00}, uint32(65536)},
+		{ets.DatapointType{Main: 13, Sub: 1}, []byte{0xFF, 0xFF, 0xFF, 0xFF}, int32(-1)},
+		{ets.DatapointType{Main: 14, Sub: 56}, []byte{0x3F, 0xC0, 0x00, 0x00}, 1.5},
+		{ets.DatapointType{Main: 16, Sub: 0}, []byte{'K', 'N', 'X', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, "KNX"},
+		{ets.DatapointType{Main: 16, Sub: 1}, []byte{0xC4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, "Ä"},
+		{ets.DatapointType{Main: 17, Sub: 1}, []byte{0x05}, uint8(5)},
+		{ets.DatapointType{Main: 18, Sub: 1}, []byte{0x85}, SceneControl{Learn: true, Scene: 5}},
+		{ets.DatapointType{Main: 19, Sub: 1}, []byte{0x7E, 0x0A, 0x10, 0x8E, 0x1E, 0x00, 0x41, 0x00}, DateTime{
+			Date:       Date{Year: 2026, Month: time.October, Day: 16},
+			TimeOfDay:  TimeOfDay{Weekday: 4, Hour: 14, Minute: 30},
+			WorkingDay: true,
+			SummerTime: true,
+		}},
+		{ets.DatapointType{Main: 20, Sub: 102}, []byte{0x03}, uint8(3)},
+		{ets.DatapointType{Main: 232, Sub: 600}, []byte{0xFF, 0x80, 0x00}, RGB{R: 0xFF, G: 0x80, B: 0x00}},
+	}
+
+	for _, test := range tests {
+		v, err := Decode(test.dpt, test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if is, want := v, test.value; is != want {
+			t.Fatalf("%s: %v != %v", test.dpt, is, want)
+		}
+
+		b, err := Encode(test.dpt, test.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if is, want := b, test.data; !bytes.Equal(is, want) {
+			t.Fatalf("%s: %X != %X", test.dpt, is, want)
+		}
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	if _, err := Decode(ets.DatapointType{Main: 9, Sub: 1}, []byte{0x01}); err == nil {
+		t.Fatal("Expected error for invalid payload length")
+	}
+
+	if _, err := Encode(ets.DatapointType{Main: 5, Sub: 10}, 256); err == nil {
+		t.Fatal("Expected error for value out of range")
+	}
+
+	if _, err := Encode(ets.DatapointType{Main: 1, Sub: 1}, "on"); err == nil {
+		t.Fatal("Expected error for invalid value type")
+	}
+
+	if IsSupported(ets.DatapointType{Main: 232, Sub: 601}) {
+		t.Fatal("Unexpected supported datapoint type")
+	}
+}
+
+func TestDecodeGroupAddress(t *testing.T) {
+	ga := ets.GroupAddress{ID: "GA-1", DatapointType: "DPST-9-1"}
+	v, err := DecodeGroupAddress(ga, []byte{0x0C, 0x1A})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := v, 21.0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
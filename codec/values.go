@@ -0,0 +1,81 @@
+package codec
+
+import (
+	"fmt"
+	"time"
+)
+
+// Control3Bit is the value of a 3-bit controlled datapoint (DPT 3.x), e.g. relative dimming.
+type Control3Bit struct {
+	// Increase is true for increasing (3.007) or down (3.008) steps.
+	Increase bool
+	// StepCode is the number of intervals (0 = break, 1..7 = 2^(StepCode-1) intervals).
+	StepCode uint8
+}
+
+// TimeOfDay is the value of a time datapoint (DPT 10.001).
+type TimeOfDay struct {
+	// Weekday is the day of the week (1 = Monday .. 7 = Sunday, 0 = no day).
+	Weekday uint8
+	Hour    uint8
+	Minute  uint8
+	Second  uint8
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// Date is the value of a date datapoint (DPT 11.001).
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   uint8
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// DateTime is the value of a date time datapoint (DPT 19.001).
+type DateTime struct {
+	Date
+	TimeOfDay
+
+	// Fault is true if the clock is faulty.
+	Fault bool
+	// WorkingDay is true if the day is a working day.
+	WorkingDay bool
+	// SummerTime is true if the time is daylight saving time.
+	SummerTime bool
+	// ExternalSync is true if the clock is synchronized with an external time source.
+	ExternalSync bool
+}
+
+// Time returns the date time in the given location.
+func (dt DateTime) Time(loc *time.Location) time.Time {
+	return time.Date(dt.Year, dt.Month, int(dt.Date.Day), int(dt.Hour), int(dt.Minute), int(dt.Second), 0, loc)
+}
+
+func (dt DateTime) String() string {
+	return fmt.Sprintf("%s %s", dt.Date, dt.TimeOfDay)
+}
+
+// SceneControl is the value of a scene control datapoint (DPT 18.001).
+type SceneControl struct {
+	// Learn is true if the scene should be stored instead of activated.
+	Learn bool
+	// Scene is the scene number (0..63).
+	Scene uint8
+}
+
+// RGB is the value of a RGB colour datapoint (DPT 232.600).
+type RGB struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+func (c RGB) String() string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
@@ -0,0 +1,331 @@
+package ets
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CatalogSectionID is the ID of a catalog section, e.g. "CS-A.2001-S.2003".
+type CatalogSectionID string
+
+// CatalogSection is a section of a product catalog.
+type CatalogSection struct {
+	ID       CatalogSectionID
+	Name     string
+	Number   string
+	Sections []CatalogSection
+	Items    []CatalogItem
+}
+
+// AllItems returns the items of the section and its subsections.
+func (s *CatalogSection) AllItems() []CatalogItem {
+	items := append([]CatalogItem{}, s.Items...)
+	for i := range s.Sections {
+		items = append(items, s.Sections[i].AllItems()...)
+	}
+
+	return items
+}
+
+// CatalogItemID is the ID of a catalog item, e.g. "CI-AMS.2D1216.2E02-1".
+type CatalogItemID string
+
+// CatalogItem is an orderable product with an application program in the catalog.
+type CatalogItem struct {
+	ID                 CatalogItemID
+	ManufacturerID     ManufacturerID
+	HardwareID         HardwareID
+	ProductID          ProductID
+	Hardware2ProgramID Hardware2ProgramID
+	Name               string
+	Number             string
+	VisibleDescription string
+}
+
+// Catalog is the product catalog of a manufacturer.
+type Catalog struct {
+	Manufacturer ManufacturerID
+	Sections     []CatalogSection
+	Languages    []Language
+}
+
+// Section returns the section with the given id.
+func (c *Catalog) Section(id CatalogSectionID) (*CatalogSection, bool) {
+	var find func(sections []CatalogSection) *CatalogSection
+	find = func(sections []CatalogSection) *CatalogSection {
+		for i := range sections {
+			if sections[i].ID == id {
+				return &sections[i]
+			}
+
+			if s := find(sections[i].Sections); s != nil {
+				return s
+			}
+		}
+
+		return nil
+	}
+
+	s := find(c.Sections)
+
+	return s, s != nil
+}
+
+// Walk calls fn for every item of the catalog with the path of sections
+// which contain the item, starting at the top-level section.
+func (c *Catalog) Walk(fn func(path []*CatalogSection, item *CatalogItem)) {
+	var walk func(path []*CatalogSection, sections []CatalogSection)
+	walk = func(path []*CatalogSection, sections []CatalogSection) {
+		for i := range sections {
+			s := &sections[i]
+			p := append(path[:len(path):len(path)], s)
+			for n := range s.Items {
+				fn(p, &s.Items[n])
+			}
+			walk(p, s.Sections)
+		}
+	}
+
+	walk(nil, c.Sections)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (c *Catalog) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Decide which schema to use based on the value of the 'xmlns' attribute.
+	ns := getNamespace(start)
+	switch ns {
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace, schema20Namespace, schema21Namespace, schema22Namespace, schema23Namespace:
+		return d.DecodeElement((*catalog11)(c), &start)
+
+	default:
+		return &UnsupportedSchemaError{Namespace: ns}
+	}
+}
+
+// DecodeCatalog parses the contents of a catalog file.
+func DecodeCatalog(r io.Reader) (*Catalog, error) {
+	c := &Catalog{}
+	if err := xml.NewDecoder(r).Decode(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CatalogFile is a catalog file.
+type CatalogFile struct {
+	Path           string
+	ManufacturerID ManufacturerID
+
+	archive *archive
+}
+
+// Decode the file in order to retrieve the catalog inside it.
+func (cf *CatalogFile) Decode() (c *Catalog, err error) {
+	r, err := cf.archive.open(cf.Path)
+	if err != nil {
+		return
+	}
+
+	c, err = DecodeCatalog(r)
+	r.Close()
+	err = decodeError(cf.Path, err)
+
+	return
+}
+
+// CatalogEntry is a catalog item joined with its hardware, product and application programs.
+type CatalogEntry struct {
+	Item     *CatalogItem
+	Path     []*CatalogSection     // sections which contain the item, starting at the top-level section
+	Hardware *Hardware             // nil if the hardware is missing
+	Product  *Product              // nil if the product is missing
+	Programs []*ApplicationProgram // application programs of the item, which are part of the archive
+}
+
+// ProductCatalog contains the catalogs of all manufacturers joined with their
+// hardware and application programs.
+type ProductCatalog struct {
+	Catalogs []*Catalog
+	Entries  []CatalogEntry
+}
+
+// NewProductCatalog returns the product catalog of the catalogs, whose items are joined
+// with the hardware and application programs of the manufacturers.
+func NewProductCatalog(catalogs []*Catalog, hardware []*HardwareData, manufacturers []*ManufacturerData) *ProductCatalog {
+	pc := &ProductCatalog{Catalogs: catalogs}
+	for _, c := range catalogs {
+		c.Walk(func(path []*CatalogSection, item *CatalogItem) {
+			e := CatalogEntry{Item: item, Path: path}
+			e.Hardware, e.Product = findProduct(hardware, item)
+
+			var ids []ApplicationProgramID
+			if e.Hardware != nil {
+				for _, hp := range e.Hardware.Hardware2Programs {
+					if hp.ID == item.Hardware2ProgramID {
+						ids = hp.ApplicationProgramIDs
+					}
+				}
+			}
+
+			for _, id := range ids {
+				if ap := findProgram(manufacturers, item.ManufacturerID, id); ap != nil {
+					e.Programs = append(e.Programs, ap)
+				}
+			}
+
+			pc.Entries = append(pc.Entries, e)
+		})
+	}
+
+	return pc
+}
+
+func findProduct(hardware []*HardwareData, item *CatalogItem) (*Hardware, *Product) {
+	for _, hd := range hardware {
+		if hd.Manufacturer != item.ManufacturerID {
+			continue
+		}
+
+		for i := range hd.Hardwares {
+			hw := &hd.Hardwares[i]
+			for n := range hw.Products {
+				if p := &hw.Products[n]; p.HardwareID == item.HardwareID && p.ID == item.ProductID {
+					return hw, p
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func findProgram(manufacturers []*ManufacturerData, m ManufacturerID, id ApplicationProgramID) *ApplicationProgram {
+	for _, md := range manufacturers {
+		if md.ID != m {
+			continue
+		}
+
+		for i := range md.Programs {
+			if md.Programs[i].ID == id {
+				return &md.Programs[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Manufacturers returns the sorted ids of the manufacturers in the catalog.
+func (pc *ProductCatalog) Manufacturers() []ManufacturerID {
+	var ids []ManufacturerID
+	for _, c := range pc.Catalogs {
+		ids = append(ids, c.Manufacturer)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// Browse returns the entries of the manufacturer in the section with the given id
+// and its subsections. An empty section id returns all entries of the manufacturer.
+func (pc *ProductCatalog) Browse(m ManufacturerID, section CatalogSectionID) []CatalogEntry {
+	var entries []CatalogEntry
+	for _, e := range pc.Entries {
+		if e.Item.ManufacturerID != m {
+			continue
+		}
+
+		if len(section) == 0 {
+			entries = append(entries, e)
+			continue
+		}
+
+		for _, s := range e.Path {
+			if s.ID == section {
+				entries = append(entries, e)
+				break
+			}
+		}
+	}
+
+	return entries
+}
+
+// Search returns the entries which contain all words of the query in the name,
+// description or number of the item, the text of the product, the name of the
+// hardware or the names of the application programs. The search is case-insensitive.
+func (pc *ProductCatalog) Search(query string) []CatalogEntry {
+	words := strings.Fields(strings.ToLower(query))
+
+	var entries []CatalogEntry
+	for _, e := range pc.Entries {
+		text := strings.ToLower(strings.Join(e.searchTexts(), "\n"))
+
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries
+}
+
+func (e CatalogEntry) searchTexts() []string {
+	texts := []string{e.Item.Name, e.Item.Number, e.Item.VisibleDescription}
+	if e.Product != nil {
+		texts = append(texts, e.Product.Text)
+	}
+
+	if e.Hardware != nil {
+		texts = append(texts, e.Hardware.Name)
+	}
+
+	for _, ap := range e.Programs {
+		texts = append(texts, ap.Name)
+	}
+
+	return texts
+}
+
+// ProductCatalog decodes the catalog, hardware and manufacturer files of the archive
+// and returns the joined product catalog.
+func (ex *ExportArchive) ProductCatalog() (*ProductCatalog, error) {
+	var catalogs []*Catalog
+	for _, cf := range ex.CatalogFiles {
+		c, err := cf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, c)
+	}
+
+	var hardware []*HardwareData
+	for _, hf := range ex.HardwareFiles {
+		hd, err := hf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		hardware = append(hardware, hd)
+	}
+
+	var manufacturers []*ManufacturerData
+	for _, mf := range ex.ManufacturerFiles {
+		md, err := mf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		manufacturers = append(manufacturers, md)
+	}
+
+	return NewProductCatalog(catalogs, hardware, manufacturers), nil
+}
@@ -0,0 +1,70 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestProductCatalog(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	pc, err := archive.ProductCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := len(pc.Manufacturers()), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	entries := pc.Browse("M-0083", "CS-A.2001")
+	if is, want := len(entries), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	e := entries[0]
+	if is, want := e.Item.ID, CatalogItemID("CI-AMS.2D1216.2E02-1"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(e.Path), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if e.Product == nil {
+		t.Fatal("Product not found")
+	}
+
+	if is, want := len(e.Programs), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := e.Programs[0].ID, ApplicationProgramID("A-0019-21-D29E"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(pc.Browse("M-0083", "CS-PS")), 0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	entries = pc.Search("präsenzmelder 6131")
+	if is, want := len(entries), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(entries[0].Programs), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	l, err := archive.Localizer("en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := l.CatalogItem(*entries[0].Item).Name, "6131/20 Busch-Presence Detector mini"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
@@ -0,0 +1,142 @@
+package ets
+
+// Flag is the value of a communication object flag. The zero value
+// FlagUnset means that the flag attribute is absent and is inherited
+// from the referenced communication object (reference).
+type Flag int
+
+const (
+	FlagUnset Flag = iota
+	FlagDisabled
+	FlagEnabled
+)
+
+func parseFlag(s string) Flag {
+	switch s {
+	case "Enabled":
+		return FlagEnabled
+	case "Disabled":
+		return FlagDisabled
+	default:
+		return FlagUnset
+	}
+}
+
+// Enabled returns true if the flag is enabled.
+func (f Flag) Enabled() bool {
+	return f == FlagEnabled
+}
+
+// IsSet returns true if the flag attribute is present.
+func (f Flag) IsSet() bool {
+	return f != FlagUnset
+}
+
+// inherit returns f if it is set, otherwise the first set flag of parents.
+func (f Flag) inherit(parents ...Flag) Flag {
+	if f.IsSet() {
+		return f
+	}
+
+	for _, p := range parents {
+		if p.IsSet() {
+			return p
+		}
+	}
+
+	return FlagUnset
+}
+
+// inheritString returns s if it is not empty, otherwise the first non-empty string of parents.
+func inheritString(s string, parents ...string) string {
+	if len(s) > 0 {
+		return s
+	}
+
+	for _, p := range parents {
+		if len(p) > 0 {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// EffectiveComObject is a communication object as it is used by ETS.
+// Attributes of the communication object instance override the attributes of
+// the communication object reference, which override the attributes of the
+// communication object. Attributes which are not set are inherited.
+type EffectiveComObject struct {
+	ID                ComObjectID
+	RefID             ComObjectRefID
+	Number            uint
+	Name              string
+	Text              string
+	Description       string
+	FunctionText      string
+	ObjectSize        string
+	DatapointType     string
+	Priority          string
+	ReadFlag          bool
+	WriteFlag         bool
+	CommunicationFlag bool
+	TransmitFlag      bool
+	UpdateFlag        bool
+	ReadOnInitFlag    bool
+}
+
+// NewEffectiveComObject merges the communication object obj, its reference ref and
+// the instance inst. Any of the arguments can be nil.
+func NewEffectiveComObject(obj *ComObject, ref *ComObjectRef, inst *ComObjectInstanceRef) EffectiveComObject {
+	if obj == nil {
+		obj = &ComObject{}
+	}
+
+	if ref == nil {
+		ref = &ComObjectRef{}
+	}
+
+	if inst == nil {
+		inst = &ComObjectInstanceRef{}
+	}
+
+	return EffectiveComObject{
+		ID:                ComObjectID(inheritString(string(inst.ComObjectID), string(ref.ComObjectID), string(obj.ID))),
+		RefID:             ComObjectRefID(inheritString(string(inst.ComObjectRefID), string(ref.ID))),
+		Number:            obj.Number,
+		Name:              inheritString(ref.Name, obj.Name),
+		Text:              inheritString(inst.Text, ref.Text, obj.Text),
+		Description:       inheritString(ref.Description, obj.Description),
+		FunctionText:      inheritString(inst.FunctionText, ref.FunctionText, obj.FunctionText),
+		ObjectSize:        inheritString(ref.ObjectSize, obj.ObjectSize),
+		DatapointType:     inheritString(inst.DatapointType, ref.DatapointType, obj.DatapointType),
+		Priority:          inheritString(inst.Priority, ref.Priority, obj.Priority),
+		ReadFlag:          inst.ReadFlag.inherit(ref.ReadFlag, obj.ReadFlag).Enabled(),
+		WriteFlag:         inst.WriteFlag.inherit(ref.WriteFlag, obj.WriteFlag).Enabled(),
+		CommunicationFlag: inst.CommunicationFlag.inherit(ref.CommunicationFlag, obj.CommunicationFlag).Enabled(),
+		TransmitFlag:      inst.TransmitFlag.inherit(ref.TransmitFlag, obj.TransmitFlag).Enabled(),
+		UpdateFlag:        inst.UpdateFlag.inherit(ref.UpdateFlag, obj.UpdateFlag).Enabled(),
+		ReadOnInitFlag:    inst.ReadOnInitFlag.inherit(ref.ReadOnInitFlag, obj.ReadOnInitFlag).Enabled(),
+	}
+}
+
+// Effective returns the effective communication object. Placeholders in the texts
+// are substituted with the parameter values and module arguments of the device.
+func (o *ResolvedComObject) Effective() EffectiveComObject {
+	return o.effective(o.Object, o.Ref)
+}
+
+func (o *ResolvedComObject) effective(obj *ComObject, ref *ComObjectRef) EffectiveComObject {
+	eff := NewEffectiveComObject(obj, ref, o.Instance)
+	if o.Device == nil {
+		return eff
+	}
+
+	if r := o.Device.TextResolver(); r != nil {
+		textParam, mi := comObjectTextContext(nonNilComObject(ref, obj))
+		eff.Text = r.Resolve(eff.Text, textParam, mi)
+		eff.FunctionText = r.Resolve(eff.FunctionText, textParam, mi)
+	}
+
+	return eff
+}
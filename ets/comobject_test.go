@@ -0,0 +1,59 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestEffectiveComObject(t *testing.T) {
+	obj := &ComObject{
+		ID:                "O-1",
+		Number:            1,
+		Text:              "Channel A",
+		FunctionText:      "Switch",
+		ObjectSize:        "1 Byte",
+		DatapointType:     "DPST-5-1",
+		ReadFlag:          FlagEnabled,
+		WriteFlag:         FlagEnabled,
+		CommunicationFlag: FlagEnabled,
+	}
+
+	ref := &ComObjectRef{
+		ID:            "R-1",
+		ComObjectID:   "O-1",
+		ObjectSize:    "1 Bit",
+		DatapointType: "DPST-1-1",
+		ReadFlag:      FlagDisabled,
+		TransmitFlag:  FlagEnabled,
+	}
+
+	inst := &ComObjectInstanceRef{
+		ComObjectID:    "O-1",
+		ComObjectRefID: "R-1",
+		Text:           "Kitchen",
+		WriteFlag:      FlagDisabled,
+		ReadFlag:       FlagEnabled,
+	}
+
+	is := NewEffectiveComObject(obj, ref, inst)
+	want := EffectiveComObject{
+		ID:                "O-1",
+		RefID:             "R-1",
+		Number:            1,
+		Text:              "Kitchen",
+		FunctionText:      "Switch",
+		ObjectSize:        "1 Bit",
+		DatapointType:     "DPST-1-1",
+		ReadFlag:          true,
+		WriteFlag:         false,
+		CommunicationFlag: true,
+		TransmitFlag:      true,
+	}
+
+	if is != want {
+		t.Fatalf("%+v != %+v", is, want)
+	}
+
+	if is := NewEffectiveComObject(obj, nil, nil); is.ObjectSize != "1 Byte" || !is.WriteFlag {
+		t.Fatalf("Unexpected %+v", is)
+	}
+}
@@ -0,0 +1,259 @@
+package ets
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NoSubtype is the subtype of a datapoint type which only specifies the main type.
+const NoSubtype = -1
+
+// DatapointType is a datapoint type (e.g. DPT-9) or subtype (e.g. DPST-9-1).
+type DatapointType struct {
+	Main int
+	Sub  int
+}
+
+// HasSubtype returns true if the subtype is specified.
+func (dpt DatapointType) HasSubtype() bool {
+	return dpt.Sub != NoSubtype
+}
+
+// MainType returns the main type of the datapoint type.
+func (dpt DatapointType) MainType() DatapointType {
+	return DatapointType{Main: dpt.Main, Sub: NoSubtype}
+}
+
+// ID returns the id of the datapoint type as used in the project files, e.g. "DPST-9-1" or "DPT-9".
+func (dpt DatapointType) ID() string {
+	if dpt.HasSubtype() {
+		return fmt.Sprintf("DPST-%d-%d", dpt.Main, dpt.Sub)
+	}
+
+	return fmt.Sprintf("DPT-%d", dpt.Main)
+}
+
+// String returns the datapoint type in the notation "9.001" or "9.xxx".
+func (dpt DatapointType) String() string {
+	if dpt.HasSubtype() {
+		return fmt.Sprintf("%d.%03d", dpt.Main, dpt.Sub)
+	}
+
+	return fmt.Sprintf("%d.xxx", dpt.Main)
+}
+
+// ParseDatapointType parses a datapoint type in the notations
+// "DPST-9-1", "DPT-9", "9.001", "9.xxx" or "9".
+func ParseDatapointType(s string) (DatapointType, error) {
+	var parts []string
+	switch {
+	case strings.HasPrefix(s, "DPST-"):
+		parts = strings.Split(strings.TrimPrefix(s, "DPST-"), "-")
+		if len(parts) != 2 {
+			return DatapointType{}, fmt.Errorf("Invalid datapoint type %s", s)
+		}
+	case strings.HasPrefix(s, "DPT-"):
+		parts = []string{strings.TrimPrefix(s, "DPT-")}
+	default:
+		parts = strings.Split(s, ".")
+		if len(parts) == 2 && parts[1] == "xxx" {
+			parts = parts[:1]
+		}
+	}
+
+	dpt := DatapointType{Sub: NoSubtype}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 16)
+		if err != nil || i > 1 {
+			return DatapointType{}, fmt.Errorf("Invalid datapoint type %s", s)
+		}
+
+		if i == 0 {
+			dpt.Main = int(n)
+		} else {
+			dpt.Sub = int(n)
+		}
+	}
+
+	return dpt, nil
+}
+
+// ParseDatapointTypes parses a space-separated list of datapoint types, e.g. "DPST-1-1 DPT-1".
+func ParseDatapointTypes(s string) ([]DatapointType, error) {
+	var dpts []DatapointType
+	for _, field := range strings.Fields(s) {
+		dpt, err := ParseDatapointType(field)
+		if err != nil {
+			return nil, err
+		}
+		dpts = append(dpts, dpt)
+	}
+
+	return dpts, nil
+}
+
+// DatapointTypes returns the datapoint types of the group address.
+func (ga GroupAddress) DatapointTypes() ([]DatapointType, error) {
+	return ParseDatapointTypes(ga.DatapointType)
+}
+
+// DatapointTypes returns the datapoint types of the communication object.
+func (o EffectiveComObject) DatapointTypes() ([]DatapointType, error) {
+	return ParseDatapointTypes(o.DatapointType)
+}
+
+// ParseObjectSize returns the number of bits of a communication object size, e.g. "1 Bit" or "2 Bytes".
+func ParseObjectSize(s string) (int, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("Invalid object size %s", s)
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, fmt.Errorf("Invalid object size %s", s)
+	}
+
+	switch fields[1] {
+	case "Bit", "Bits":
+		return n, nil
+	case "Byte", "Bytes":
+		return 8 * n, nil
+	default:
+		return 0, fmt.Errorf("Invalid object size %s", s)
+	}
+}
+
+// DatapointTypeValue is a named value of a datapoint type, e.g. of an enumeration.
+type DatapointTypeValue struct {
+	Value int
+	Text  string
+}
+
+// DatapointTypeDefinition describes a datapoint type as defined in the master data.
+type DatapointTypeDefinition struct {
+	Type           DatapointType
+	Name           string
+	Text           string
+	SizeInBit      int
+	VariableLength bool
+	Unit           string
+	Min            *float64
+	Max            *float64
+	Values         []DatapointTypeValue
+}
+
+// DatapointTypeRegistry contains the definitions of the datapoint types and subtypes.
+type DatapointTypeRegistry struct {
+	types map[DatapointType]*DatapointTypeDefinition
+}
+
+// NewDatapointTypeRegistry returns a registry containing the definitions.
+func NewDatapointTypeRegistry(defs []DatapointTypeDefinition) *DatapointTypeRegistry {
+	r := &DatapointTypeRegistry{
+		types: map[DatapointType]*DatapointTypeDefinition{},
+	}
+
+	for i := range defs {
+		r.types[defs[i].Type] = &defs[i]
+	}
+
+	return r
+}
+
+// Lookup returns the definition of the datapoint type.
+func (r *DatapointTypeRegistry) Lookup(dpt DatapointType) (*DatapointTypeDefinition, bool) {
+	def, ok := r.types[dpt]
+	return def, ok
+}
+
+// Types returns the definitions of all datapoint types sorted by their main and sub numbers.
+func (r *DatapointTypeRegistry) Types() []*DatapointTypeDefinition {
+	defs := make([]*DatapointTypeDefinition, 0, len(r.types))
+	for _, def := range r.types {
+		defs = append(defs, def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].Type.Main != defs[j].Type.Main {
+			return defs[i].Type.Main < defs[j].Type.Main
+		}
+		return defs[i].Type.Sub < defs[j].Type.Sub
+	})
+
+	return defs
+}
+
+// SizeInBit returns the size of the datapoint type in bits.
+func (r *DatapointTypeRegistry) SizeInBit(dpt DatapointType) (int, bool) {
+	if def, ok := r.Lookup(dpt); ok && def.SizeInBit > 0 {
+		return def.SizeInBit, true
+	}
+
+	if def, ok := r.Lookup(dpt.MainType()); ok {
+		return def.SizeInBit, true
+	}
+
+	return 0, false
+}
+
+// IsCompatible returns true if the datapoint type can be used with a communication
+// object of the given size, e.g. "1 Bit".
+func (r *DatapointTypeRegistry) IsCompatible(dpt DatapointType, objectSize string) (bool, error) {
+	bits, err := ParseObjectSize(objectSize)
+	if err != nil {
+		return false, err
+	}
+
+	def, ok := r.Lookup(dpt.MainType())
+	if !ok {
+		return false, fmt.Errorf("Unknown datapoint type %s", dpt)
+	}
+
+	if def.VariableLength {
+		return true, nil
+	}
+
+	return def.SizeInBit == bits, nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (r *DatapointTypeRegistry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Decide which schema to use based on the value of the 'xmlns' attribute.
+	ns := getNamespace(start)
+	switch ns {
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace, schema20Namespace, schema21Namespace, schema22Namespace, schema23Namespace:
+		return d.DecodeElement((*datapointTypeRegistry11)(r), &start)
+
+	default:
+		return &UnsupportedSchemaError{Namespace: ns}
+	}
+}
+
+// DecodeDatapointTypes parses the datapoint types of a master data file (knx_master.xml).
+func DecodeDatapointTypes(r io.Reader) (*DatapointTypeRegistry, error) {
+	reg := &DatapointTypeRegistry{}
+	if err := xml.NewDecoder(r).Decode(reg); err != nil {
+		return nil, err
+	}
+
+	return reg, nil
+}
+
+// DatapointTypes returns the datapoint types defined in the master data file of the archive.
+func (ex *ExportArchive) DatapointTypes() (*DatapointTypeRegistry, error) {
+	if ex.MasterFile == nil {
+		return nil, fmt.Errorf("Missing master data file %s", masterFileName)
+	}
+
+	m, err := ex.MasterFile.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.DatapointTypes, nil
+}
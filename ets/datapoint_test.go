@@ -0,0 +1,102 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestParseDatapointTypes(t *testing.T) {
+	dpts, err := ParseDatapointTypes("DPST-1-1 DPT-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := len(dpts), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := dpts[0], (DatapointType{1, 1}); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := dpts[1], (DatapointType{1, NoSubtype}); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	for s, want := range map[string]DatapointType{
+		"9.001":     {9, 1},
+		"232.600":   {232, 600},
+		"16.xxx":    {16, NoSubtype},
+		"DPST-16-0": {16, 0},
+	} {
+		is, err := ParseDatapointType(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if is != want {
+			t.Fatalf("%v != %v", is, want)
+		}
+	}
+
+	if is, want := (DatapointType{9, 1}).String(), "9.001"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestDatapointTypeRegistry(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	reg, err := archive.DatapointTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def, ok := reg.Lookup(DatapointType{9, 1})
+	if !ok {
+		t.Fatal("DPT 9.001 not found")
+	}
+
+	if is, want := def.Name, "DPT_Value_Temp"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := def.Unit, "°C"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := def.SizeInBit, 16; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if def.Min == nil || *def.Min != -273 {
+		t.Fatalf("Unexpected min value %v", def.Min)
+	}
+
+	tests := []struct {
+		dpt        DatapointType
+		objectSize string
+		compatible bool
+	}{
+		{DatapointType{1, 1}, "1 Bit", true},
+		{DatapointType{1, 1}, "1 Byte", false},
+		{DatapointType{5, 1}, "1 Byte", true},
+		{DatapointType{9, 1}, "2 Bytes", true},
+		{DatapointType{16, 0}, "14 Bytes", true},
+		{DatapointType{3, 7}, "4 Bit", true},
+	}
+
+	for _, test := range tests {
+		compatible, err := reg.IsCompatible(test.dpt, test.objectSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if is, want := compatible, test.compatible; is != want {
+			t.Fatalf("%v %s: %v != %v", test.dpt, test.objectSize, is, want)
+		}
+	}
+}
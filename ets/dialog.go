@@ -0,0 +1,223 @@
+package ets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DialogNode is an element of the parameter dialog of a device as shown in ETS.
+type DialogNode struct {
+	Kind     string        `json:"kind"` // "program", "channel", "block", "separator" or "parameter"
+	ID       string        `json:"id,omitempty"`
+	Text     string        `json:"text"`
+	Value    string        `json:"value,omitempty"`    // displayed value of a parameter
+	RawValue string        `json:"rawValue,omitempty"` // configured value of a parameter
+	Children []*DialogNode `json:"children,omitempty"`
+}
+
+// WriteText writes the dialog as an indented text tree.
+func (n *DialogNode) WriteText(w io.Writer) error {
+	return n.writeText(w, 0)
+}
+
+func (n *DialogNode) writeText(w io.Writer, depth int) error {
+	line := n.Text
+	if n.Kind == "parameter" && len(n.Value) > 0 {
+		line = fmt.Sprintf("%s: %s", n.Text, n.Value)
+	}
+
+	if len(line) > 0 {
+		line = strings.Repeat("  ", depth) + line
+	}
+
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		return err
+	}
+
+	for _, child := range n.Children {
+		if err := child.writeText(w, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes the dialog as indented JSON.
+func (n *DialogNode) WriteJSON(w io.Writer) error {
+	b, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+
+	return err
+}
+
+// RenderDialog returns the parameter dialog of the application program for a device with
+// the texts in the languages of the localizer, which may be nil. Only channels, blocks and parameters which are active
+// and visible for the device's parameter values are included. The device may be nil to
+// render the default values. Modules are rendered with the parameter and argument values
+// of their module instances.
+func RenderDialog(ap *ApplicationProgram, di *DeviceInstance, l *Localizer) *DialogNode {
+	r := &dialogRenderer{
+		program:   ap,
+		values:    ap.ParameterValues(di),
+		localizer: l,
+		texts:     NewTextResolver(ap, di),
+		params:    map[ParameterID]*Parameter{},
+		refs:      map[ParameterRefID]*ParameterRef{},
+	}
+
+	for i := range ap.Parameters {
+		r.params[ap.Parameters[i].ID] = &ap.Parameters[i]
+	}
+
+	for i := range ap.ParameterRefs {
+		r.refs[ap.ParameterRefs[i].ID] = &ap.ParameterRefs[i]
+	}
+
+	root := &DialogNode{
+		Kind: "program",
+		ID:   string(ap.ID),
+		Text: r.text("", "Name", ap.Name),
+	}
+	root.Children = r.render(ap.Dynamic)
+
+	return root
+}
+
+type dialogRenderer struct {
+	program   *ApplicationProgram
+	values    map[ParameterRefID]string
+	localizer *Localizer
+	texts     *TextResolver
+	params    map[ParameterID]*Parameter
+	refs      map[ParameterRefID]*ParameterRef
+	args      map[string]string // argument values by name while rendering a module instance
+}
+
+// text returns the translated attribute of an element of the application program.
+// The argument placeholders are substituted while rendering a module instance.
+func (r *dialogRenderer) text(id string, attr, def string) string {
+	text := r.localizer.Text(programElementID(r.program.ManufacturerID, r.program.ID, id), attr, def)
+	if r.args != nil {
+		text = substituteArguments(text, r.args)
+	}
+
+	return text
+}
+
+func (r *dialogRenderer) render(nodes []DynamicNode) []*DialogNode {
+	var result []*DialogNode
+	for _, n := range nodes {
+		switch n.Kind {
+		case "ChannelIndependentBlock":
+			result = append(result, r.render(n.Children)...)
+		case "Channel":
+			if children := r.render(n.Children); len(children) > 0 {
+				result = append(result, &DialogNode{
+					Kind:     "channel",
+					ID:       n.ID,
+					Text:     r.nodeText(n),
+					Children: children,
+				})
+			}
+		case "ParameterBlock":
+			if n.Access == "None" {
+				continue
+			}
+			result = append(result, &DialogNode{
+				Kind:     "block",
+				ID:       n.ID,
+				Text:     r.nodeText(n),
+				Children: r.render(n.Children),
+			})
+		case "ParameterSeparator":
+			result = append(result, &DialogNode{
+				Kind: "separator",
+				ID:   n.ID,
+				Text: strings.TrimSpace(r.text(n.ID, "Text", n.Text)),
+			})
+		case "ParameterRefRef":
+			if p := r.renderParameter(ParameterRefID(n.RefID)); p != nil {
+				result = append(result, p)
+			}
+		case "choose":
+			for _, when := range chooseWhen(n, r.values[n.ParamRefID]) {
+				result = append(result, r.render(when.Children)...)
+			}
+		case "Module":
+			result = append(result, r.renderModule(n)...)
+		}
+	}
+
+	return result
+}
+
+// renderModule renders the Dynamic section of the module definition of a Module element
+// with the parameter and argument values of the module instance.
+func (r *dialogRenderer) renderModule(n DynamicNode) []*DialogNode {
+	mi := moduleInstances([]DynamicNode{n})[0]
+	md, ok := r.program.ModuleDef(mi.ModuleDefID)
+	if !ok {
+		return nil
+	}
+
+	mr := *r
+	mr.values = md.InstanceValues(mi, r.values)
+	mr.args = md.ArgumentValues(mi)
+
+	return mr.render(md.Dynamic)
+}
+
+// nodeText returns the translated text of a channel or parameter block.
+func (r *dialogRenderer) nodeText(n DynamicNode) string {
+	text := r.text(n.ID, "Text", n.Text)
+	return strings.TrimSpace(r.texts.Resolve(text, n.TextParamRefID, ""))
+}
+
+func (r *dialogRenderer) renderParameter(id ParameterRefID) *DialogNode {
+	ref, ok := r.refs[id]
+	if !ok || !r.program.IsParameterVisible(*ref) {
+		return nil
+	}
+
+	p, ok := r.params[ref.ParameterID]
+	if !ok {
+		return nil
+	}
+
+	text := r.text(string(p.ID), "Text", p.Text)
+	if len(ref.Text) > 0 {
+		text = r.text(string(ref.ID), "Text", ref.Text)
+	}
+
+	value := r.values[id]
+	node := &DialogNode{
+		Kind:     "parameter",
+		ID:       string(id),
+		Text:     strings.TrimSpace(text),
+		RawValue: value,
+	}
+
+	switch p.Type.Kind {
+	case ParameterTypeNone:
+		node.RawValue = ""
+	case ParameterTypeEnum:
+		node.Value = value
+		if enum, ok := p.Type.Enumeration(value); ok {
+			node.Value = r.text(enum.ID, "Text", enum.Text)
+		}
+	default:
+		node.Value = value
+		suffix := r.text(string(p.ID), "SuffixText", p.SuffixText)
+		if len(suffix) > 0 {
+			node.Value = fmt.Sprintf("%s %s", value, suffix)
+		}
+	}
+
+	return node
+}
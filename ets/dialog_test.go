@@ -0,0 +1,94 @@
+package ets
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderDialog(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	var md *ManufacturerData
+	var ap *ApplicationProgram
+	for _, mf := range archive.ManufacturerFiles {
+		if md, err = mf.Decode(); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range md.Programs {
+			if md.Programs[i].ID == "A-0019-21-D29E" {
+				ap = &md.Programs[i]
+			}
+		}
+
+		if ap != nil {
+			break
+		}
+	}
+
+	if ap == nil {
+		t.Fatal("Application program not found")
+	}
+
+	dialog := RenderDialog(ap, nil, NewLocalizer([]LanguageID{"de-DE"}, md.Languages))
+	if is, want := dialog.Children[0].Text, "Allgemein"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := RenderDialog(ap, nil, NewLocalizer([]LanguageID{"en-US"}, md.Languages)).Children[0].Text, "General"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	var text bytes.Buffer
+	if err := dialog.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(text.String(), "\n    Kanal A: Schaltausgang\n") {
+		t.Fatalf("Unexpected text\n%s", text.String())
+	}
+
+	var buf bytes.Buffer
+	if err := dialog.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded DialogNode
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := len(decoded.Children), len(dialog.Children); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestRenderModuleDialog(t *testing.T) {
+	md, err := DecodeManufacturerData(strings.NewReader(testModuleData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ap := &md.Programs[0]
+	di := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			{RefID: "MD-1_M-2_P-1_R-1", Value: "0"},
+		},
+	}
+
+	var text bytes.Buffer
+	if err := RenderDialog(ap, di, nil).WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Modules\n  Channel A\n    General\n      Enabled: 1\n  Channel B\n    General\n      Enabled: 0\n"
+	if is := text.String(); is != want {
+		t.Fatalf("%q != %q", is, want)
+	}
+}
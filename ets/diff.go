@@ -0,0 +1,350 @@
+package ets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChangeKind is the kind of a change between two projects.
+type ChangeKind string
+
+const (
+	ChangeAdded       ChangeKind = "added"
+	ChangeRemoved     ChangeKind = "removed"
+	ChangeRenamed     ChangeKind = "renamed"
+	ChangeMoved       ChangeKind = "moved"
+	ChangeReaddressed ChangeKind = "readdressed"
+	ChangeModified    ChangeKind = "modified"
+)
+
+// Change is a change of an element between two projects.
+// Old and New contain a summary of the element for added and removed elements,
+// otherwise the old and new value of the changed field.
+type Change struct {
+	Kind  ChangeKind `json:"kind"`
+	ID    string     `json:"id"` // id of the element including the project id, e.g. "P-0497-0_GA-1"
+	Field string     `json:"field,omitempty"`
+	Old   string     `json:"old,omitempty"`
+	New   string     `json:"new,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s %s: %s", c.Kind, c.ID, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("%s %s: %s", c.Kind, c.ID, c.Old)
+	default:
+		return fmt.Sprintf("%s %s %s: %q -> %q", c.Kind, c.ID, c.Field, c.Old, c.New)
+	}
+}
+
+// ProjectDiff contains the changes between two versions of a project.
+type ProjectDiff struct {
+	GroupRanges    []Change `json:"groupRanges"`
+	GroupAddresses []Change `json:"groupAddresses"`
+	Devices        []Change `json:"devices"`
+	ComObjects     []Change `json:"comObjects"`
+	Spaces         []Change `json:"spaces"`
+}
+
+// IsEmpty returns true if the projects are equal.
+func (d *ProjectDiff) IsEmpty() bool {
+	return len(d.GroupRanges)+len(d.GroupAddresses)+len(d.Devices)+len(d.ComObjects)+len(d.Spaces) == 0
+}
+
+// WriteJSON writes the diff as indented JSON.
+func (d *ProjectDiff) WriteJSON(w io.Writer) error {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+
+	return err
+}
+
+// WriteText writes the diff as a report with one line per change.
+func (d *ProjectDiff) WriteText(w io.Writer) error {
+	if d.IsEmpty() {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+
+	sections := []struct {
+		title   string
+		changes []Change
+	}{
+		{"Group ranges", d.GroupRanges},
+		{"Group addresses", d.GroupAddresses},
+		{"Devices", d.Devices},
+		{"Communication objects", d.ComObjects},
+		{"Spaces", d.Spaces},
+	}
+
+	for _, s := range sections {
+		if len(s.changes) == 0 {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", s.title); err != nil {
+			return err
+		}
+
+		for _, c := range s.changes {
+			if _, err := fmt.Fprintf(w, "  %s\n", c); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DiffProjects returns the changes from the project from to the project to.
+// Elements are matched by their ids. Group addresses are reported in the notation
+// of the given style, e.g. the AddressStyle of the project info.
+func DiffProjects(from, to *Project, style GroupAddressStyle) *ProjectDiff {
+	return &ProjectDiff{
+		GroupRanges:    diffElements(groupRangeElements(from, style), groupRangeElements(to, style)),
+		GroupAddresses: diffElements(groupAddressElements(from, style), groupAddressElements(to, style)),
+		Devices:        diffElements(deviceElements(from), deviceElements(to)),
+		ComObjects:     diffElements(comObjectElements(from), comObjectElements(to)),
+		Spaces:         diffElements(spaceElements(from), spaceElements(to)),
+	}
+}
+
+// diffElement is an element of a project reduced to the fields which are compared.
+type diffElement struct {
+	ID      string
+	Summary string
+	Fields  []diffField
+}
+
+type diffField struct {
+	Kind  ChangeKind // kind of the change if the value changes
+	Name  string
+	Value string
+}
+
+func diffElements(from, to []diffElement) []Change {
+	olds := map[string]diffElement{}
+	for _, e := range from {
+		olds[e.ID] = e
+	}
+
+	news := map[string]diffElement{}
+	var changes []Change
+	for _, e := range to {
+		news[e.ID] = e
+		o, ok := olds[e.ID]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, ID: e.ID, New: e.Summary})
+			continue
+		}
+
+		for i, f := range e.Fields {
+			if of := o.Fields[i]; of.Value != f.Value {
+				changes = append(changes, Change{Kind: f.Kind, ID: e.ID, Field: f.Name, Old: of.Value, New: f.Value})
+			}
+		}
+	}
+
+	for _, e := range from {
+		if _, ok := news[e.ID]; !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, ID: e.ID, Old: e.Summary})
+		}
+	}
+
+	return changes
+}
+
+func qualifiedID(p ProjectID, id string) string {
+	if len(p) == 0 {
+		return id
+	}
+
+	return string(p) + "_" + id
+}
+
+func groupRangeElements(p *Project, style GroupAddressStyle) []diffElement {
+	var elems []diffElement
+	var walk func(ranges []GroupRange, parent GroupRangeID)
+	walk = func(ranges []GroupRange, parent GroupRangeID) {
+		for _, gr := range ranges {
+			elems = append(elems, diffElement{
+				ID:      string(gr.ID),
+				Summary: gr.Name,
+				Fields: []diffField{
+					{ChangeRenamed, "Name", gr.Name},
+					{ChangeModified, "RangeStart", gr.RangeStart.Format(style)},
+					{ChangeModified, "RangeEnd", gr.RangeEnd.Format(style)},
+					{ChangeMoved, "Parent", string(parent)},
+				},
+			})
+			walk(gr.SubRanges, gr.ID)
+		}
+	}
+
+	for _, inst := range p.Installations {
+		walk(inst.GroupAddresses, "")
+	}
+
+	return elems
+}
+
+func groupAddressElements(p *Project, style GroupAddressStyle) []diffElement {
+	var elems []diffElement
+	for i := range p.Installations {
+		walkGroupRanges(p.Installations[i].GroupAddresses, func(gr *GroupRange) {
+			for _, ga := range gr.Addresses {
+				address := ga.Address.Format(style)
+				elems = append(elems, diffElement{
+					ID:      qualifiedID(ga.ProjectID, string(ga.ID)),
+					Summary: strings.TrimSpace(address + " " + ga.Name),
+					Fields: []diffField{
+						{ChangeRenamed, "Name", ga.Name},
+						{ChangeReaddressed, "Address", address},
+						{ChangeModified, "DatapointType", ga.DatapointType},
+						{ChangeModified, "Description", ga.Description},
+						{ChangeMoved, "Range", string(gr.ID)},
+					},
+				})
+			}
+		})
+	}
+
+	return elems
+}
+
+// walkDevices calls fn for the devices of the project with the address of their line,
+// e.g. "1.1", or an empty string for unassigned devices.
+func walkDevices(p *Project, fn func(di *DeviceInstance, line string)) {
+	for i := range p.Installations {
+		inst := &p.Installations[i]
+		for j := range inst.Topology {
+			area := &inst.Topology[j]
+			for k := range area.Lines {
+				line := &area.Lines[k]
+				addr := fmt.Sprintf("%d.%d", area.Address, line.Address)
+				for l := range line.Devices {
+					fn(&line.Devices[l], addr)
+				}
+			}
+		}
+
+		for j := range inst.UnassignedDevices {
+			fn(&inst.UnassignedDevices[j], "")
+		}
+	}
+}
+
+func deviceElements(p *Project) []diffElement {
+	var elems []diffElement
+	walkDevices(p, func(di *DeviceInstance, line string) {
+		var addr string
+		if di.IndividualAddress != nil {
+			addr = di.IndividualAddress.String()
+		}
+
+		elems = append(elems, diffElement{
+			ID:      qualifiedID(di.ProjectID, string(di.ID)),
+			Summary: strings.TrimSpace(addr + " " + di.Name),
+			Fields: []diffField{
+				{ChangeRenamed, "Name", di.Name},
+				{ChangeMoved, "Line", line},
+				{ChangeReaddressed, "IndividualAddress", addr},
+				{ChangeModified, "Product", strings.Join([]string{string(di.ManufacturerID), string(di.HardwareID), string(di.ProductID)}, "_")},
+				{ChangeModified, "Hardware2Program", string(di.Hardware2ProgramID)},
+			},
+		})
+	})
+
+	return elems
+}
+
+func comObjectElements(p *Project) []diffElement {
+	var elems []diffElement
+	walkDevices(p, func(di *DeviceInstance, line string) {
+		for _, obj := range di.ComObjects {
+			var ids []string
+			for _, id := range []string{string(obj.ModuleInstanceID), string(obj.ComObjectID), string(obj.ComObjectRefID)} {
+				if len(id) > 0 {
+					ids = append(ids, id)
+				}
+			}
+
+			links := make([]string, len(obj.Connectors))
+			for i, c := range obj.Connectors {
+				links[i] = string(c.RefID)
+			}
+
+			elems = append(elems, diffElement{
+				ID:      qualifiedID(di.ProjectID, string(di.ID)+"_"+strings.Join(ids, "_")),
+				Summary: strings.Join(links, " "),
+				Fields: []diffField{
+					{ChangeModified, "Links", strings.Join(links, " ")},
+					{ChangeModified, "Text", obj.Text},
+					{ChangeModified, "FunctionText", obj.FunctionText},
+					{ChangeModified, "DatapointType", obj.DatapointType},
+					{ChangeModified, "Priority", obj.Priority},
+					{ChangeModified, "ReadFlag", flagString(obj.ReadFlag)},
+					{ChangeModified, "WriteFlag", flagString(obj.WriteFlag)},
+					{ChangeModified, "CommunicationFlag", flagString(obj.CommunicationFlag)},
+					{ChangeModified, "TransmitFlag", flagString(obj.TransmitFlag)},
+					{ChangeModified, "UpdateFlag", flagString(obj.UpdateFlag)},
+					{ChangeModified, "ReadOnInitFlag", flagString(obj.ReadOnInitFlag)},
+				},
+			})
+		}
+	})
+
+	return elems
+}
+
+func flagString(f Flag) string {
+	switch f {
+	case FlagEnabled:
+		return "Enabled"
+	case FlagDisabled:
+		return "Disabled"
+	default:
+		return ""
+	}
+}
+
+func spaceElements(p *Project) []diffElement {
+	var elems []diffElement
+	var walk func(spaces []Space, parent string)
+	walk = func(spaces []Space, parent string) {
+		for _, s := range spaces {
+			id := qualifiedID(s.ProjectID, string(s.ID))
+			devices := make([]string, len(s.DeviceInstanceIDs))
+			for i, di := range s.DeviceInstanceIDs {
+				devices[i] = string(di)
+			}
+			sort.Strings(devices)
+
+			elems = append(elems, diffElement{
+				ID:      id,
+				Summary: strings.TrimSpace(s.Type + " " + s.Name),
+				Fields: []diffField{
+					{ChangeRenamed, "Name", s.Name},
+					{ChangeModified, "Type", s.Type},
+					{ChangeMoved, "Parent", parent},
+					{ChangeModified, "Devices", strings.Join(devices, " ")},
+				},
+			})
+			walk(s.SubSpaces, id)
+		}
+	}
+
+	for _, inst := range p.Installations {
+		walk(inst.Locations, "")
+	}
+
+	return elems
+}
@@ -0,0 +1,93 @@
+package ets
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDiffProjects(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	info, err := archive.ProjectFiles[0].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finst := archive.ProjectFiles[0].InstallationFiles[0]
+	old, err := finst.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cur, err := finst.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := DiffProjects(old, cur, info.AddressStyle); !diff.IsEmpty() {
+		t.Fatalf("Unexpected changes %v", diff)
+	}
+
+	inst := &cur.Installations[0]
+	gr := &inst.GroupAddresses[0].SubRanges[0]
+	gr.Addresses[0].Name = "Licht Flur"
+	gr.Addresses = append(gr.Addresses, GroupAddress{ID: "GA-2", ProjectID: "P-0497-0", Name: "Dimmen", Address: 2})
+
+	// Move the second device to the main line.
+	lines := inst.Topology[1].Lines
+	dev := lines[1].Devices[1]
+	addr := NewIndividualAddress(1, 0, 1)
+	dev.IndividualAddress = &addr
+	dev.ComObjects[0].Connectors = append(dev.ComObjects[0].Connectors, Connector{Receive: true, RefID: "GA-2"})
+	lines[0].Devices = append(lines[0].Devices, dev)
+	lines[1].Devices = lines[1].Devices[:1]
+
+	diff := DiffProjects(old, cur, info.AddressStyle)
+
+	want := &ProjectDiff{
+		GroupAddresses: []Change{
+			Change{Kind: ChangeRenamed, ID: "P-0497-0_GA-1", Field: "Name", Old: "Licht", New: "Licht Flur"},
+			Change{Kind: ChangeAdded, ID: "P-0497-0_GA-2", New: "0/0/2 Dimmen"},
+		},
+		Devices: []Change{
+			Change{Kind: ChangeMoved, ID: "P-0497-0_DI-2", Field: "Line", Old: "1.1", New: "1.0"},
+			Change{Kind: ChangeReaddressed, ID: "P-0497-0_DI-2", Field: "IndividualAddress", Old: "1.1.2", New: "1.0.1"},
+		},
+		ComObjects: []Change{
+			Change{Kind: ChangeModified, ID: "P-0497-0_DI-2_O-0_R-10000", Field: "Links", Old: "GA-1", New: "GA-1 GA-2"},
+		},
+	}
+
+	if d := deep.Equal(diff, want); d != nil {
+		t.Fatal(d)
+	}
+
+	var buf bytes.Buffer
+	if err := diff.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "Devices\n  moved P-0497-0_DI-2 Line: \"1.1\" -> \"1.0\"\n") {
+		t.Fatalf("Unexpected report\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := diff.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"kind": "readdressed"`) {
+		t.Fatalf("Unexpected JSON\n%s", buf.String())
+	}
+
+	if is, want := DiffProjects(old, cur, GroupAddressStyleFree).GroupAddresses[1].New, "2 Dimmen"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
@@ -0,0 +1,296 @@
+package ets
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DynamicNode is an element of the Dynamic section of an application program,
+// e.g. a ChannelIndependentBlock, Channel, ParameterBlock, ParameterSeparator,
+// ParameterRefRef, ComObjectRefRef, choose or when element.
+type DynamicNode struct {
+	Kind           string // name of the element, e.g. "ParameterBlock"
+	ID             string
+	RefID          string // RefId of ParameterRefRef and ComObjectRefRef elements
+	ParamRefID     ParameterRefID
+	TextParamRefID ParameterRefID // TextParameterRefId of channels and parameter blocks
+	Name           string
+	Text           string
+	Number         string
+	Access         string
+	Value          string // value of module arguments
+	Test           string // test of when elements
+	Default        bool   // true for the default when element
+	Children       []DynamicNode
+}
+
+// DynamicResult contains the communication objects and parameters which are
+// active for a set of parameter values.
+type DynamicResult struct {
+	ObjectRefs      []ComObjectRefID
+	ParameterRefs   []ParameterRefID
+	ModuleInstances []ModuleInstanceID
+
+	objectRefs       map[ComObjectRefID]bool
+	parameterRefs    map[ParameterRefID]bool
+	moduleInstances  map[ModuleInstanceID]bool
+	moduleObjectRefs map[ModuleInstanceID]map[ComObjectRefID]bool
+}
+
+// HasObjectRef returns true if the communication object reference is active.
+func (r *DynamicResult) HasObjectRef(id ComObjectRefID) bool {
+	return r.objectRefs[id]
+}
+
+// HasParameterRef returns true if the parameter reference is active.
+func (r *DynamicResult) HasParameterRef(id ParameterRefID) bool {
+	return r.parameterRefs[id]
+}
+
+// HasModuleInstance returns true if the module instance is active.
+func (r *DynamicResult) HasModuleInstance(id ModuleInstanceID) bool {
+	return r.moduleInstances[id]
+}
+
+// HasModuleObjectRef returns true if the module instance is active and the communication
+// object reference is active in the Dynamic section of its module definition.
+func (r *DynamicResult) HasModuleObjectRef(mi ModuleInstanceID, id ComObjectRefID) bool {
+	return r.moduleObjectRefs[mi][id]
+}
+
+func (r *DynamicResult) addObjectRef(id ComObjectRefID) {
+	if len(id) > 0 && !r.objectRefs[id] {
+		r.objectRefs[id] = true
+		r.ObjectRefs = append(r.ObjectRefs, id)
+	}
+}
+
+func (r *DynamicResult) addParameterRef(id ParameterRefID) {
+	if len(id) > 0 && !r.parameterRefs[id] {
+		r.parameterRefs[id] = true
+		r.ParameterRefs = append(r.ParameterRefs, id)
+	}
+}
+
+// EvaluateDynamic evaluates the Dynamic section of the application program for the
+// parameter values (see ParameterValues) and returns the active communication objects
+// and parameters. If the program has no Dynamic section, all references are active.
+// The Dynamic sections of the module definitions are evaluated for every active module instance.
+func (ap *ApplicationProgram) EvaluateDynamic(values map[ParameterRefID]string) *DynamicResult {
+	r := &DynamicResult{
+		objectRefs:       map[ComObjectRefID]bool{},
+		parameterRefs:    map[ParameterRefID]bool{},
+		moduleInstances:  map[ModuleInstanceID]bool{},
+		moduleObjectRefs: map[ModuleInstanceID]map[ComObjectRefID]bool{},
+	}
+
+	if ap.Dynamic == nil {
+		for _, ref := range ap.ObjectRefs {
+			if len(ref.ModuleInstanceID) == 0 {
+				r.addObjectRef(ref.ID)
+			}
+		}
+		for _, ref := range ap.ParameterRefs {
+			r.addParameterRef(ref.ID)
+		}
+		for _, mi := range ap.ModuleInstances {
+			r.moduleInstances[mi.ID] = true
+			r.ModuleInstances = append(r.ModuleInstances, mi.ID)
+		}
+	} else {
+		walkDynamic(ap.Dynamic, values, func(n DynamicNode) {
+			switch n.Kind {
+			case "ComObjectRefRef":
+				r.addObjectRef(parseIds(n.RefID).ComObjectRef)
+			case "ParameterRefRef":
+				r.addParameterRef(ParameterRefID(n.RefID))
+			case "Module":
+				if id := ModuleInstanceID(n.ID); !r.moduleInstances[id] {
+					r.moduleInstances[id] = true
+					r.ModuleInstances = append(r.ModuleInstances, id)
+				}
+			}
+		})
+	}
+
+	for _, mi := range ap.ModuleInstances {
+		if md, ok := ap.ModuleDef(mi.ModuleDefID); ok && r.moduleInstances[mi.ID] {
+			r.evaluateModule(md, mi, values)
+		}
+	}
+
+	return r
+}
+
+// evaluateModule evaluates the Dynamic section of the module definition for the module instance.
+// If the module definition has no Dynamic section, all its references are active.
+func (r *DynamicResult) evaluateModule(md *ModuleDef, mi ModuleInstance, values map[ParameterRefID]string) {
+	refs := map[ComObjectRefID]bool{}
+	r.moduleObjectRefs[mi.ID] = refs
+
+	if len(md.Dynamic) == 0 {
+		for _, ref := range md.ObjectRefs {
+			refs[ref.ID] = true
+		}
+		return
+	}
+
+	walkDynamic(md.Dynamic, md.InstanceValues(mi, values), func(n DynamicNode) {
+		switch n.Kind {
+		case "ComObjectRefRef":
+			if id := parseIds(n.RefID).ComObjectRef; len(id) > 0 {
+				refs[id] = true
+			}
+		case "ParameterRefRef":
+			r.addParameterRef(ParameterRefID(n.RefID))
+		}
+	})
+}
+
+// ActiveObjectRefs returns the communication object references which are active for the parameter values.
+// The references of a module instance are returned if the module instance is active and the references
+// are active for the parameter values of the module instance.
+func (ap *ApplicationProgram) ActiveObjectRefs(values map[ParameterRefID]string) []ComObjectRef {
+	r := ap.EvaluateDynamic(values)
+	refs := []ComObjectRef{}
+	for _, ref := range ap.ObjectRefs {
+		if len(ref.ModuleInstanceID) > 0 {
+			if r.HasModuleObjectRef(ref.ModuleInstanceID, ref.ID) {
+				refs = append(refs, ref)
+			}
+		} else if r.HasObjectRef(ref.ID) {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// ActiveParameterRefs returns the parameter references which are active for the parameter values.
+// Use IsParameterVisible to omit parameters which are active but not shown in ETS.
+func (ap *ApplicationProgram) ActiveParameterRefs(values map[ParameterRefID]string) []ParameterRef {
+	r := ap.EvaluateDynamic(values)
+	refs := []ParameterRef{}
+	for _, ref := range ap.ParameterRefs {
+		if r.HasParameterRef(ref.ID) {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// walkDynamic calls fn for every node which is active for the parameter values.
+// Only the when elements of a choose element whose test matches are walked.
+func walkDynamic(nodes []DynamicNode, values map[ParameterRefID]string, fn func(DynamicNode)) {
+	for _, n := range nodes {
+		fn(n)
+
+		if n.Kind != "choose" {
+			walkDynamic(n.Children, values, fn)
+			continue
+		}
+
+		for _, when := range chooseWhen(n, values[n.ParamRefID]) {
+			walkDynamic(when.Children, values, fn)
+		}
+	}
+}
+
+// chooseWhen returns the when elements of a choose element whose test matches the value.
+// The default when element is returned if no test matches.
+func chooseWhen(choose DynamicNode, value string) []DynamicNode {
+	var matches []DynamicNode
+	var def []DynamicNode
+	for _, when := range choose.Children {
+		if when.Kind != "when" {
+			continue
+		}
+
+		if when.Default {
+			def = append(def, when)
+		} else if matchesTest(when.Test, value) {
+			matches = append(matches, when)
+		}
+	}
+
+	if len(matches) == 0 {
+		return def
+	}
+
+	return matches
+}
+
+// matchesTest returns true if the value matches the test of a when element.
+// The test is a space-separated list of values or comparisons, e.g. "1 2", "!=0" or "<5".
+func matchesTest(test, value string) bool {
+	for _, field := range strings.Fields(test) {
+		op := ""
+		for _, prefix := range []string{"!=", "<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(field, prefix) {
+				op = prefix
+				field = strings.TrimPrefix(field, prefix)
+				break
+			}
+		}
+
+		cmp, ok := compareValues(value, field)
+		if !ok {
+			if op == "!=" {
+				return true
+			}
+			continue
+		}
+
+		switch op {
+		case "", "=":
+			if cmp == 0 {
+				return true
+			}
+		case "!=":
+			if cmp != 0 {
+				return true
+			}
+		case "<":
+			if cmp < 0 {
+				return true
+			}
+		case "<=":
+			if cmp <= 0 {
+				return true
+			}
+		case ">":
+			if cmp > 0 {
+				return true
+			}
+		case ">=":
+			if cmp >= 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// compareValues compares the values numerically or, if one of them is not a number, textually.
+// The result is false if non-numeric values are not equal, because they cannot be ordered.
+func compareValues(a, b string) (int, bool) {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX != nil || errY != nil {
+		if a == b {
+			return 0, true
+		}
+		return 0, false
+	}
+
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
@@ -0,0 +1,64 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestEvaluateDynamic(t *testing.T) {
+	ap := decodeTestProgram(t, "A-0019-21-D29E")
+
+	if ap.Dynamic == nil {
+		t.Fatal("Dynamic section not decoded")
+	}
+
+	defaults := ap.EvaluateDynamic(ap.ParameterValues(nil))
+	if !defaults.HasObjectRef("R-615") {
+		t.Fatal("R-615 should be active")
+	}
+
+	if defaults.HasObjectRef("R-10003") {
+		t.Fatal("R-10003 should not be active")
+	}
+
+	if is, max := len(defaults.ObjectRefs), len(ap.ObjectRefs); is >= max {
+		t.Fatalf("%v active of %v objects", is, max)
+	}
+
+	di := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			ParameterInstanceRef{RefID: "P-1_R-1000", Value: "0"},
+		},
+	}
+
+	configured := ap.EvaluateDynamic(ap.ParameterValues(di))
+	if !configured.HasObjectRef("R-10003") {
+		t.Fatal("R-10003 should be active")
+	}
+
+	if is, want := len(ap.ActiveObjectRefs(ap.ParameterValues(di))), len(configured.ObjectRefs); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestMatchesTest(t *testing.T) {
+	tests := []struct {
+		test  string
+		value string
+		want  bool
+	}{
+		{"1", "1", true},
+		{"1", "2", false},
+		{"1 2 3", "2", true},
+		{"!=2", "3", true},
+		{"!=2", "2", false},
+		{"<5", "4", true},
+		{">=5", "4", false},
+		{"on", "on", true},
+	}
+
+	for _, test := range tests {
+		if is, want := matchesTest(test.test, test.value), test.want; is != want {
+			t.Fatalf("%s %s: %v != %v", test.test, test.value, is, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ets
+
+import (
+	"compress/flate"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/yeka/zip"
+)
+
+var (
+	// ErrPasswordRequired is returned if an archive contains encrypted files
+	// but no password was provided.
+	ErrPasswordRequired = errors.New("Password required")
+
+	// ErrWrongPassword is returned if the encrypted files of an archive
+	// cannot be decrypted with the provided password.
+	ErrWrongPassword = errors.New("Wrong password")
+
+	// ErrCorruptArchive is returned if an archive or one of its files is malformed.
+	ErrCorruptArchive = errors.New("Corrupt archive")
+
+	// ErrUnsupportedSchema is returned if a file uses an unknown schema.
+	// The returned error is of type *UnsupportedSchemaError.
+	ErrUnsupportedSchema = errors.New("Unsupported schema")
+)
+
+// UnsupportedSchemaError is returned if a file uses an unknown schema namespace.
+type UnsupportedSchemaError struct {
+	Namespace string
+}
+
+func (e *UnsupportedSchemaError) Error() string {
+	return fmt.Sprintf("Unexpected namespace '%s'", e.Namespace)
+}
+
+// Is reports whether target is ErrUnsupportedSchema.
+func (e *UnsupportedSchemaError) Is(target error) bool {
+	return target == ErrUnsupportedSchema
+}
+
+// archiveError maps an error which occurred while reading a file from an
+// archive to ErrWrongPassword or ErrCorruptArchive. Errors of encrypted files are
+// most likely caused by a wrong password, because ZipCrypto doesn't verify the password.
+func archiveError(err error, encrypted bool) error {
+	switch err {
+	case nil, io.EOF:
+		return err
+
+	case zip.ErrPassword, zip.ErrAuthentication:
+		return ErrWrongPassword
+
+	case zip.ErrChecksum, zip.ErrFormat, zip.ErrAlgorithm, zip.ErrDecryption, io.ErrUnexpectedEOF:
+		break
+
+	default:
+		if _, ok := err.(flate.CorruptInputError); !ok {
+			return err
+		}
+	}
+
+	if encrypted {
+		return ErrWrongPassword
+	}
+
+	return fmt.Errorf("%w: %v", ErrCorruptArchive, err)
+}
+
+// decodeError maps a malformed xml document in the file with the given name
+// to ErrCorruptArchive. Other errors are returned unchanged.
+func decodeError(name string, err error) error {
+	var syntaxErr *xml.SyntaxError
+	switch {
+	case err == io.EOF, err == io.ErrUnexpectedEOF, errors.As(err, &syntaxErr):
+		return fmt.Errorf("%s: %w: %v", name, ErrCorruptArchive, err)
+
+	default:
+		return err
+	}
+}
+
+// archiveFileReader maps the errors of reading a file from an archive.
+type archiveFileReader struct {
+	rc        io.ReadCloser
+	encrypted bool
+}
+
+func (r *archiveFileReader) Read(b []byte) (int, error) {
+	n, err := r.rc.Read(b)
+	return n, archiveError(err, r.encrypted)
+}
+
+func (r *archiveFileReader) Close() error {
+	return r.rc.Close()
+}
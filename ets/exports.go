@@ -1,6 +1,7 @@
 package ets
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -17,17 +18,20 @@ import (
 type InstallationFile struct {
 	Path           string
 	InstallationID string
+
+	archive *archive
 }
 
 // Decode the file in order to retrieve the project inside it.
 func (i *InstallationFile) Decode() (p *Project, err error) {
-	r, err := os.Open(i.Path)
+	r, err := i.archive.open(i.Path)
 	if err != nil {
 		return
 	}
 
 	p, err = DecodeProject(r)
 	r.Close()
+	err = decodeError(i.Path, err)
 
 	return
 }
@@ -38,17 +42,20 @@ type ProjectFile struct {
 
 	ProjectID         string
 	InstallationFiles []InstallationFile
+
+	archive *archive
 }
 
 // Decode the file in order to retrieve the project info inside it.
 func (pf *ProjectFile) Decode() (pi *ProjectInfo, err error) {
-	r, err := os.Open(pf.Path)
+	r, err := pf.archive.open(pf.Path)
 	if err != nil {
 		return
 	}
 
 	pi, err = DecodeProjectInfo(r)
 	r.Close()
+	err = decodeError(pf.Path, err)
 
 	return
 }
@@ -60,6 +67,7 @@ func newProjectFile(archive *archive, file string) (projFile ProjectFile) {
 
 	projFile.Path = file
 	projFile.ProjectID = projectDir
+	projFile.archive = archive
 
 	// Search for the project installation file.
 	for _, file := range archive.File {
@@ -71,6 +79,7 @@ func newProjectFile(archive *archive, file string) (projFile ProjectFile) {
 			projFile.InstallationFiles = append(projFile.InstallationFiles, InstallationFile{
 				Path:           file,
 				InstallationID: matches[1],
+				archive:        archive,
 			})
 		}
 	}
@@ -84,7 +93,8 @@ func newHardwareFile(archive *archive, file string) (hwFile HardwareFile) {
 	projectDir := path.Dir(file)
 
 	hwFile.Path = file
-	hwFile.ManufacturerID = ManufacturerID(filepath.Base(projectDir))
+	hwFile.ManufacturerID = ManufacturerID(path.Base(projectDir))
+	hwFile.archive = archive
 
 	return
 }
@@ -95,17 +105,20 @@ type ManufacturerFile struct {
 
 	ManufacturerID       ManufacturerID
 	ApplicationProgramID ApplicationProgramID
+
+	archive *archive
 }
 
 // Decode the file in order to retrieve the manufacturer data inside it.
 func (mf *ManufacturerFile) Decode() (md *ManufacturerData, err error) {
-	r, err := os.Open(mf.Path)
+	r, err := mf.archive.open(mf.Path)
 	if err != nil {
 		return
 	}
 
 	md, err = DecodeManufacturerData(r)
 	r.Close()
+	err = decodeError(mf.Path, err)
 
 	return
 }
@@ -114,24 +127,151 @@ func (mf *ManufacturerFile) Decode() (md *ManufacturerData, err error) {
 type HardwareFile struct {
 	Path           string
 	ManufacturerID ManufacturerID
+
+	archive *archive
 }
 
 // Decode the file in order to retrieve the manufacturer data inside it.
 func (hf *HardwareFile) Decode() (hd *HardwareData, err error) {
-	r, err := os.Open(hf.Path)
+	r, err := hf.archive.open(hf.Path)
 	if err != nil {
 		return
 	}
 
 	hd, err = DecodeHardwareData(r)
 	r.Close()
+	err = decodeError(hf.Path, err)
 
 	return
 }
 
+// archive is an in-memory view of a zip archive. Nested zip archives
+// (e.g. P-XXXX.zip) are read into memory and their files are listed
+// in a directory named after the nested archive.
 type archive struct {
+	// Dir used to be the directory the archive was extracted to.
+	//
+	// Deprecated: Archives are no longer extracted to disk and Dir is always empty.
 	Dir  string
 	File []string
+
+	files    map[string]*zip.File
+	pwd      func(string) string
+	verified bool
+	closer   io.Closer
+}
+
+func newArchive(zr *zip.Reader, pwd func(string) string) (*archive, error) {
+	a := &archive{
+		files: map[string]*zip.File{},
+		pwd:   pwd,
+	}
+
+	if err := a.add(zr, ""); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// add adds the files of the zip archive to the directory dir.
+func (a *archive) add(zr *zip.Reader, dir string) error {
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		name := path.Join(dir, f.Name)
+
+		var b []byte // contents of the file, if already read
+		if f.IsEncrypted() {
+			var err error
+			if b, err = a.setPassword(f); err != nil {
+				return err
+			}
+		}
+
+		a.File = append(a.File, name)
+		a.files[name] = f
+
+		if fext := path.Ext(name); fext == ".zip" {
+			if b == nil {
+				var err error
+				if b, err = readZipFile(f); err != nil {
+					return err
+				}
+			}
+
+			nested, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+			if err != nil {
+				return archiveError(err, false)
+			}
+
+			if err := a.add(nested, strings.TrimSuffix(name, fext)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setPassword sets the password of the encrypted file f. The password
+// is validated by reading the first encrypted file in the archive, whose
+// contents are returned. For any other file nil is returned.
+func (a *archive) setPassword(f *zip.File) ([]byte, error) {
+	password := a.pwd(f.Name)
+	if len(password) == 0 {
+		return nil, ErrPasswordRequired
+	}
+
+	f.SetPassword(password)
+	if a.verified {
+		return nil, nil
+	}
+
+	b, err := readZipFile(f)
+	if err != nil {
+		return nil, err
+	}
+	a.verified = true
+
+	return b, nil
+}
+
+// open returns a reader for the file with the given name. If the file
+// is not part of an archive, it is opened from the file system.
+func (a *archive) open(name string) (io.ReadCloser, error) {
+	if a == nil {
+		return os.Open(name)
+	}
+
+	f, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("File %s not found in archive", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, archiveError(err, f.IsEncrypted())
+	}
+
+	return &archiveFileReader{rc, f.IsEncrypted()}, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, archiveError(err, f.IsEncrypted())
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, archiveError(err, f.IsEncrypted())
+	}
+
+	return b, nil
 }
 
 // ExportArchive is a handle to an exported archive (.knxproj or .knxprod).
@@ -141,24 +281,77 @@ type ExportArchive struct {
 	ProjectFiles      []ProjectFile
 	ManufacturerFiles []ManufacturerFile
 	HardwareFiles     []HardwareFile
+	CatalogFiles      []CatalogFile
+	MasterFile        *MasterFile // nil if the archive contains no master data
 }
 
 // OpenExportArchive opens the exported archive located at given path.
+// The archive is read without extracting its contents to disk.
+//
+// ErrPasswordRequired or ErrWrongPassword is returned if the archive contains
+// encrypted files and the password is missing or wrong.
 func OpenExportArchive(path, password string) (*ExportArchive, error) {
-	tmpPath, err := ioutil.TempDir("", "ets")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	ex, err := NewExportArchive(f, fi.Size(), password)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	ex.closer = f
+
+	return ex, nil
+}
+
+// OpenExportArchiveReader reads the exported archive from r into memory.
+// This is useful for archives which are not stored in a file, e.g. HTTP uploads.
+func OpenExportArchiveReader(r io.Reader, password string) (*ExportArchive, error) {
+	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
 
-	pwd := func(file string) string {
-		return password
+	return NewExportArchive(bytes.NewReader(b), int64(len(b)), password)
+}
+
+// NewExportArchive returns the exported archive read from r, which has the given size.
+// The password is derived from the given password as required by the ETS version
+// which created the archive. If the derived password is wrong, the given password is used as is.
+func NewExportArchive(r io.ReaderAt, size int64, password string) (*ExportArchive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, archiveError(err, false)
+	}
+
+	ns, err := archiveNamespace(zr)
+	if err != nil {
+		return nil, archiveError(err, false)
+	}
+
+	var archive *archive
+	for _, candidate := range archivePasswords(password, ns) {
+		candidate := candidate
+		pwd := func(file string) string {
+			return candidate
+		}
+
+		if archive, err = newArchive(zr, pwd); err != ErrWrongPassword {
+			break
+		}
 	}
 
-	files, err := NestedUnzip(path, tmpPath, pwd)
 	if err != nil {
 		return nil, err
 	}
-	archive := &archive{tmpPath, files}
 
 	ex := &ExportArchive{archive: archive}
 	if err = ex.findFiles(); err != nil {
@@ -168,8 +361,11 @@ func OpenExportArchive(path, password string) (*ExportArchive, error) {
 	return ex, nil
 }
 
+// Delete used to remove the files extracted from the archive.
+//
+// Deprecated: Archives are no longer extracted to disk and there is nothing to delete.
 func (ex *ExportArchive) Delete() error {
-	return os.RemoveAll(ex.Dir)
+	return nil
 }
 
 var (
@@ -177,7 +373,7 @@ var (
 	projectZipFileRe   = regexp.MustCompile("(p|P)-([^.]+).zip$")
 	manufacturerFileRe = regexp.MustCompile("(m|M)-([0-9a-zA-Z]+)/(m|M)-([^.]+).xml$")
 	hardwareFileRe     = regexp.MustCompile("(m|M)-([0-9a-zA-Z]+)/(h|H)ardware.xml$")
-	// TODO: Figure out if '/' is a universal path seperator in ZIP files.
+	catalogFileRe      = regexp.MustCompile("(m|M)-([0-9a-zA-Z]+)/(c|C)atalog.xml$")
 )
 
 func (ex *ExportArchive) findFiles() error {
@@ -185,8 +381,8 @@ func (ex *ExportArchive) findFiles() error {
 		if projectMetaFileRe.MatchString(file) {
 			ex.ProjectFiles = append(ex.ProjectFiles, newProjectFile(ex.archive, file))
 		} else if matches := manufacturerFileRe.FindStringSubmatch(file); matches != nil {
-			fname := filepath.Base(file)
-			fbase := strings.TrimSuffix(fname, filepath.Ext(file))
+			fname := path.Base(file)
+			fbase := strings.TrimSuffix(fname, path.Ext(file))
 			ids := strings.Split(fbase, "_")
 			if len(ids) != 2 {
 				return fmt.Errorf("Invalid manufacturer file name %s", fname)
@@ -195,9 +391,18 @@ func (ex *ExportArchive) findFiles() error {
 				Path:                 file,
 				ManufacturerID:       ManufacturerID(ids[0]),
 				ApplicationProgramID: ApplicationProgramID(ids[1]),
+				archive:              ex.archive,
 			})
 		} else if hardwareFileRe.MatchString(file) {
 			ex.HardwareFiles = append(ex.HardwareFiles, newHardwareFile(ex.archive, file))
+		} else if catalogFileRe.MatchString(file) {
+			ex.CatalogFiles = append(ex.CatalogFiles, CatalogFile{
+				Path:           file,
+				ManufacturerID: ManufacturerID(path.Base(path.Dir(file))),
+				archive:        ex.archive,
+			})
+		} else if file == masterFileName {
+			ex.MasterFile = &MasterFile{Path: file, archive: ex.archive}
 		}
 	}
 	return nil
@@ -205,7 +410,10 @@ func (ex *ExportArchive) findFiles() error {
 
 // Close the archive handle.
 func (ex *ExportArchive) Close() error {
-	// return ex.archive.Close()
+	if ex.closer != nil {
+		return ex.closer.Close()
+	}
+
 	return nil
 }
 
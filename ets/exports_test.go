@@ -0,0 +1,111 @@
+package ets
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOpenExportArchiveReader(t *testing.T) {
+	f, err := os.Open("Testproject-5.knxproj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	archive, err := OpenExportArchiveReader(f, "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	if is, want := len(archive.ProjectFiles), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	fproj := archive.ProjectFiles[0]
+	if is, want := fproj.ProjectID, "P-0497"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(fproj.InstallationFiles), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if _, err := fproj.InstallationFiles[0].Decode(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fmanu := range archive.ManufacturerFiles {
+		if _, err := fmanu.Decode(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, fhw := range archive.HardwareFiles {
+		if _, err := fhw.Decode(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOpenExportArchivePassword(t *testing.T) {
+	tests := []struct {
+		path     string
+		password string
+		err      error
+	}{
+		{"Testproject-5.knxproj", "", ErrPasswordRequired},
+		{"Testproject-5.knxproj", "wrong", ErrWrongPassword},
+		{"Testproject.knxproj", "", ErrPasswordRequired},
+		{"Testproject.knxproj", "wrong", ErrWrongPassword},
+		{"Testproject.knxproj", "testabcdefg", nil},                                  // user password
+		{"Testproject.knxproj", "cZZMPZALQGFmqdguE19tRaZeJ/L/Mp8GiogUi9vohSA=", nil}, // derived password
+		{"Testproject-6.0.knxproj", "", nil},
+	}
+
+	for _, test := range tests {
+		archive, err := OpenExportArchive(test.path, test.password)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("%s: %v != %v", test.path, err, test.err)
+		}
+
+		if archive != nil {
+			archive.Close()
+		}
+	}
+}
+
+func TestUnsupportedSchema(t *testing.T) {
+	_, err := DecodeProject(strings.NewReader(`<KNX xmlns="http://knx.org/xml/project/99"></KNX>`))
+	if !errors.Is(err, ErrUnsupportedSchema) {
+		t.Fatal(err)
+	}
+
+	var schemaErr *UnsupportedSchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatal(err)
+	}
+
+	if is, want := schemaErr.Namespace, "http://knx.org/xml/project/99"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestDecodeCorruptFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "ets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	f.WriteString(`<KNX xmlns="http://knx.org/xml/project/21"><Project Id="P-0497"><Installations>`)
+	f.Close()
+
+	inst := InstallationFile{Path: f.Name()}
+	if _, err := inst.Decode(); !errors.Is(err, ErrCorruptArchive) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,107 @@
+package ets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GroupAddressValue is the 16 bit value of a group address.
+type GroupAddressValue uint16
+
+// NewThreeLevelGroupAddress returns the group address main/middle/sub.
+func NewThreeLevelGroupAddress(main, middle, sub uint8) GroupAddressValue {
+	return GroupAddressValue(uint16(main&0x1F)<<11 | uint16(middle&0x07)<<8 | uint16(sub))
+}
+
+// NewTwoLevelGroupAddress returns the group address main/sub.
+func NewTwoLevelGroupAddress(main uint8, sub uint16) GroupAddressValue {
+	return GroupAddressValue(uint16(main&0x1F)<<11 | sub&0x07FF)
+}
+
+// Main returns the main group.
+func (ga GroupAddressValue) Main() uint8 {
+	return uint8(ga >> 11 & 0x1F)
+}
+
+// Middle returns the middle group of a three-level group address.
+func (ga GroupAddressValue) Middle() uint8 {
+	return uint8(ga >> 8 & 0x07)
+}
+
+// Sub returns the sub group of a three-level group address.
+func (ga GroupAddressValue) Sub() uint8 {
+	return uint8(ga & 0xFF)
+}
+
+// String returns the group address in three-level notation, e.g. "1/2/3".
+func (ga GroupAddressValue) String() string {
+	return ga.Format(GroupAddressStyleThree)
+}
+
+// Format returns the group address in the notation of the given style,
+// e.g. "1/2/3" (three-level), "1/515" (two-level) or "2563" (free).
+func (ga GroupAddressValue) Format(style GroupAddressStyle) string {
+	switch style {
+	case GroupAddressStyleThree:
+		return fmt.Sprintf("%d/%d/%d", ga.Main(), ga.Middle(), ga.Sub())
+	case GroupAddressStyleTwo:
+		return fmt.Sprintf("%d/%d", ga.Main(), uint16(ga&0x07FF))
+	default:
+		return strconv.FormatUint(uint64(ga), 10)
+	}
+}
+
+// ParseGroupAddress parses a group address in three-level ("1/2/3"),
+// two-level ("1/515") or free ("2563") notation.
+func ParseGroupAddress(s string) (GroupAddressValue, error) {
+	parts := strings.Split(s, "/")
+	bits := []int{}
+	switch len(parts) {
+	case 1:
+		bits = []int{16}
+	case 2:
+		bits = []int{5, 11}
+	case 3:
+		bits = []int{5, 3, 8}
+	default:
+		return 0, fmt.Errorf("Invalid group address %s", s)
+	}
+
+	var ga uint16
+	for i, part := range parts {
+		v, err := strconv.ParseUint(strings.TrimSpace(part), 10, bits[i])
+		if err != nil {
+			return 0, fmt.Errorf("Invalid group address %s", s)
+		}
+		ga = ga<<uint(bits[i]) | uint16(v)
+	}
+
+	return GroupAddressValue(ga), nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The group address is always
+// encoded in three-level notation, regardless of the address style of the project,
+// so that the encoded form doesn't depend on the project. Exports which show the
+// addresses in the style of the project, e.g. GroupAddressUsageMatrix.WriteJSON and
+// DiffProjects, format them with Format instead.
+func (ga GroupAddressValue) MarshalText() ([]byte, error) {
+	return []byte(ga.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. All notations
+// supported by ParseGroupAddress are accepted.
+func (ga *GroupAddressValue) UnmarshalText(text []byte) error {
+	v, err := ParseGroupAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*ga = v
+
+	return nil
+}
+
+// Contains returns true if the group address lies within the bounds of the group range.
+func (gr GroupRange) Contains(ga GroupAddressValue) bool {
+	return gr.RangeStart <= ga && ga <= gr.RangeEnd
+}
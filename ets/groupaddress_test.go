@@ -0,0 +1,77 @@
+package ets
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestGroupAddressValue(t *testing.T) {
+	tests := []struct {
+		s     string
+		style GroupAddressStyle
+		ga    GroupAddressValue
+	}{
+		{"1/2/3", GroupAddressStyleThree, 2563},
+		{"1/515", GroupAddressStyleTwo, 2563},
+		{"2563", GroupAddressStyleFree, 2563},
+		{"31/7/255", GroupAddressStyleThree, 65535},
+		{"0/0/1", GroupAddressStyleThree, 1},
+	}
+
+	for _, test := range tests {
+		ga, err := ParseGroupAddress(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if is, want := ga, test.ga; is != want {
+			t.Fatalf("%v != %v", is, want)
+		}
+
+		if is, want := ga.Format(test.style), test.s; is != want {
+			t.Fatalf("%v != %v", is, want)
+		}
+	}
+
+	if is, want := fmt.Sprint(NewThreeLevelGroupAddress(1, 2, 3)), "1/2/3"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	for _, s := range []string{"32/0/0", "1/8/0", "1/2048", "65536", "1/2/3/4", "a/b/c", ""} {
+		if _, err := ParseGroupAddress(s); err == nil {
+			t.Fatalf("Expected error for %s", s)
+		}
+	}
+}
+
+func TestGroupAddressValueJSON(t *testing.T) {
+	b, err := json.Marshal(NewThreeLevelGroupAddress(1, 2, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := string(b), `"1/2/3"`; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	var ga GroupAddressValue
+	if err := json.Unmarshal([]byte(`"1/515"`), &ga); err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := ga, NewTwoLevelGroupAddress(1, 515); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestGroupRangeContains(t *testing.T) {
+	gr := GroupRange{RangeStart: 1, RangeEnd: 255}
+	if !gr.Contains(1) || !gr.Contains(255) {
+		t.Fatal("Expected group address in range")
+	}
+
+	if gr.Contains(0) || gr.Contains(256) {
+		t.Fatal("Unexpected group address in range")
+	}
+}
@@ -2,7 +2,6 @@ package ets
 
 import (
 	"encoding/xml"
-	"fmt"
 	"io"
 )
 
@@ -26,10 +25,13 @@ type Language struct {
 type ProductID string
 
 type Product struct {
-	ID             ProductID
-	ManufacturerID ManufacturerID
-	HardwareID     HardwareID
-	Text           string
+	ID                ProductID
+	ManufacturerID    ManufacturerID
+	HardwareID        HardwareID
+	Text              string
+	OrderNumber       string
+	IsRailMounted     bool
+	WidthInMillimeter float64 // 0 if unknown
 }
 
 type Hardware2ProgramID string
@@ -38,16 +40,29 @@ type Hardware2Program struct {
 	HardwareID            HardwareID
 	ID                    Hardware2ProgramID
 	ApplicationProgramIDs []ApplicationProgramID
+	MediumTypes           []MediumTypeID
+	Hash                  string
+	Checksum              string
+	LoaderHash            string
 }
 
-// HardwareID is the ID of a manufacturer.
+// HardwareID is the ID of a hardware.
 type HardwareID string
 
 type Hardware struct {
-	ID                HardwareID
-	Name              string
-	Products          []Product
-	Hardware2Programs []Hardware2Program
+	ID                    HardwareID
+	ManufacturerID        ManufacturerID
+	Name                  string
+	SerialNumber          string
+	VersionNumber         uint
+	BusCurrent            float64 // in mA
+	IsPowerSupply         bool
+	IsCoupler             bool
+	IsIPEnabled           bool
+	HasIndividualAddress  bool
+	HasApplicationProgram bool
+	Products              []Product
+	Hardware2Programs     []Hardware2Program
 }
 
 // HardwareData contains hardware-specific data.
@@ -66,7 +81,7 @@ func (md *HardwareData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) err
 		return d.DecodeElement((*hardwareData11)(md), &start)
 
 	default:
-		return fmt.Errorf("Unexpected namespace '%s'", ns)
+		return &UnsupportedSchemaError{Namespace: ns}
 	}
 }
 
@@ -0,0 +1,68 @@
+package ets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeHardwareData(t *testing.T) {
+	hd, err := DecodeHardwareData(strings.NewReader(`<KNX xmlns="http://knx.org/xml/project/21">
+  <ManufacturerData>
+    <Manufacturer RefId="M-0083">
+      <Hardware>
+        <Hardware Id="M-0083_H-4-2" Name="Schaltaktor" SerialNumber="4" VersionNumber="2" BusCurrent="10" HasIndividualAddress="true" HasApplicationProgram="true">
+          <Products>
+            <Product Id="M-0083_H-4-2_P-AMS.2D1216.2E02" Text="AMS-1216.02" OrderNumber="AMS-1216.02" IsRailMounted="true" WidthInMillimeter="213" />
+          </Products>
+          <Hardware2Programs>
+            <Hardware2Program Id="M-0083_H-4-2_HP-0019-21-D29E" MediumTypes="MT-0 MT-5" Hash="tDHesFNALU4XTRJmRi0bVUdgHbw=">
+              <ApplicationProgramRef RefId="M-0083_A-0019-21-D29E" />
+            </Hardware2Program>
+          </Hardware2Programs>
+        </Hardware>
+        <Hardware Id="M-0083_H-9" Name="Spannungsversorgung" SerialNumber="9" VersionNumber="1" IsPowerSupply="true" HasIndividualAddress="false" HasApplicationProgram="false" />
+      </Hardware>
+    </Manufacturer>
+  </ManufacturerData>
+</KNX>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := len(hd.Hardwares), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	hw := hd.Hardwares[0]
+	if is, want := hw.ID, HardwareID("H-4-2"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := hw.Name, "Schaltaktor"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := hw.BusCurrent, 10.0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if !hw.HasIndividualAddress || !hw.HasApplicationProgram || hw.IsPowerSupply {
+		t.Fatalf("Invalid flags %+v", hw)
+	}
+
+	if is, want := hw.Products[0].WidthInMillimeter, 213.0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := hw.Products[0].OrderNumber, "AMS-1216.02"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(hw.Hardware2Programs[0].MediumTypes), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if !hd.Hardwares[1].IsPowerSupply {
+		t.Fatal("Expected power supply")
+	}
+}
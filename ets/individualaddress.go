@@ -0,0 +1,102 @@
+package ets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IndividualAddress is the individual (physical) address area.line.device of a device.
+// The area and line are encoded with 4 bits and the device with 8 bits.
+type IndividualAddress uint16
+
+// NewIndividualAddress returns the individual address area.line.device.
+func NewIndividualAddress(area, line, device uint8) IndividualAddress {
+	return IndividualAddress(uint16(area&0x0F)<<12 | uint16(line&0x0F)<<8 | uint16(device))
+}
+
+// Area returns the area of the address.
+func (ia IndividualAddress) Area() uint8 {
+	return uint8(ia >> 12 & 0x0F)
+}
+
+// Line returns the line of the address.
+func (ia IndividualAddress) Line() uint8 {
+	return uint8(ia >> 8 & 0x0F)
+}
+
+// Device returns the device of the address.
+func (ia IndividualAddress) Device() uint8 {
+	return uint8(ia & 0xFF)
+}
+
+// String returns the address in the notation area.line.device.
+func (ia IndividualAddress) String() string {
+	return fmt.Sprintf("%d.%d.%d", ia.Area(), ia.Line(), ia.Device())
+}
+
+// Compare returns -1, 0 or +1 depending on whether ia is lower than,
+// equal to or higher than other.
+func (ia IndividualAddress) Compare(other IndividualAddress) int {
+	switch {
+	case ia < other:
+		return -1
+	case ia > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ParseIndividualAddress parses an individual address in the notation area.line.device.
+func ParseIndividualAddress(s string) (IndividualAddress, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("Invalid individual address %s", s)
+	}
+
+	var ia uint16
+	for i, bits := range []int{4, 4, 8} {
+		v, err := strconv.ParseUint(strings.TrimSpace(parts[i]), 10, bits)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid individual address %s", s)
+		}
+		ia = ia<<uint(bits) | uint16(v)
+	}
+
+	return IndividualAddress(ia), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (ia IndividualAddress) MarshalText() ([]byte, error) {
+	return []byte(ia.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ia *IndividualAddress) UnmarshalText(text []byte) error {
+	v, err := ParseIndividualAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*ia = v
+
+	return nil
+}
+
+// setIndividualAddresses sets the fully qualified individual addresses
+// of the area, its lines and their devices. The devices of segments share
+// the storage of the line's devices and get the same addresses.
+func (a *Area) setIndividualAddresses() {
+	a.IndividualAddress = NewIndividualAddress(uint8(a.Address), 0, 0)
+	for i := range a.Lines {
+		l := &a.Lines[i]
+		l.IndividualAddress = NewIndividualAddress(uint8(a.Address), uint8(l.Address), 0)
+		for j := range l.Devices {
+			d := &l.Devices[j]
+			if d.hasAddress {
+				addr := NewIndividualAddress(uint8(a.Address), uint8(l.Address), uint8(d.Address))
+				d.IndividualAddress = &addr
+			}
+		}
+	}
+}
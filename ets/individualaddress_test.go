@@ -0,0 +1,73 @@
+package ets
+
+import (
+	"strings"
+	"testing"
+)
+
+func newIndividualAddress(area, line, device uint8) *IndividualAddress {
+	ia := NewIndividualAddress(area, line, device)
+	return &ia
+}
+
+func TestIndividualAddress(t *testing.T) {
+	ia, err := ParseIndividualAddress("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := ia, NewIndividualAddress(1, 2, 3); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := uint16(ia), uint16(0x1203); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := ia.String(), "1.2.3"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := ia.Compare(NewIndividualAddress(1, 3, 0)), -1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	for _, s := range []string{"16.0.0", "1.16.0", "1.1.256", "1.1", ""} {
+		if _, err := ParseIndividualAddress(s); err == nil {
+			t.Fatalf("Expected error for %s", s)
+		}
+	}
+}
+
+func TestUnassignedIndividualAddress(t *testing.T) {
+	doc := `<KNX xmlns="http://knx.org/xml/project/20">
+  <Project Id="P-0001">
+    <Installations>
+      <Installation Name="">
+        <Topology>
+          <Area Id="P-0001-0_A-1" Address="2">
+            <Line Id="P-0001-0_L-1" Address="3">
+              <DeviceInstance Id="P-0001-0_DI-1" Address="0" />
+              <DeviceInstance Id="P-0001-0_DI-2" />
+            </Line>
+          </Area>
+        </Topology>
+      </Installation>
+    </Installations>
+  </Project>
+</KNX>`
+
+	proj, err := DecodeProject(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices := proj.Installations[0].Topology[0].Lines[0].Devices
+	if ia := devices[0].IndividualAddress; ia == nil || *ia != NewIndividualAddress(2, 3, 0) {
+		t.Fatalf("Unexpected address %v", ia)
+	}
+
+	if ia := devices[1].IndividualAddress; ia != nil {
+		t.Fatalf("Unexpected address %v", ia)
+	}
+}
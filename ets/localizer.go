@@ -0,0 +1,214 @@
+package ets
+
+import (
+	"strings"
+)
+
+// Localizer returns the texts of manufacturer and hardware data in the preferred
+// languages. Texts which are not translated to any of the languages are returned
+// in the default language of the data.
+type Localizer struct {
+	preferred    []LanguageID
+	translations map[LanguageID]map[TranslationRefID]map[string]string
+}
+
+// NewLocalizer returns a localizer for the languages in the order of preference,
+// e.g. "de-DE" with "en-US" as fallback, and the translations of the manufacturer
+// and hardware data.
+func NewLocalizer(preferred []LanguageID, languages ...[]Language) *Localizer {
+	l := &Localizer{
+		preferred:    preferred,
+		translations: map[LanguageID]map[TranslationRefID]map[string]string{},
+	}
+
+	for _, langs := range languages {
+		l.Add(langs)
+	}
+
+	return l
+}
+
+// Add adds the translations of the languages.
+func (l *Localizer) Add(languages []Language) {
+	for _, lang := range languages {
+		ts, ok := l.translations[lang.ID]
+		if !ok {
+			ts = map[TranslationRefID]map[string]string{}
+			l.translations[lang.ID] = ts
+		}
+
+		for _, t := range lang.Translations {
+			texts, ok := ts[t.RefID]
+			if !ok {
+				texts = map[string]string{}
+				ts[t.RefID] = texts
+			}
+
+			for attr, text := range t.Texts {
+				texts[attr] = text
+			}
+		}
+	}
+}
+
+// Text returns the translated attribute (e.g. "Text") of the element with the given id
+// in the first preferred language which translates it, otherwise def.
+// A nil localizer returns def.
+func (l *Localizer) Text(id TranslationRefID, attr, def string) string {
+	if l == nil {
+		return def
+	}
+
+	for _, lang := range l.preferred {
+		if s, ok := l.translations[lang][id][attr]; ok && len(s) > 0 {
+			return s
+		}
+	}
+
+	return def
+}
+
+// ComObject returns the communication object with translated texts.
+func (l *Localizer) ComObject(obj ComObject) ComObject {
+	id := programElementID(obj.ManufacturerID, obj.ApplicationProgramID, string(obj.ModuleID), string(obj.ID))
+	obj.Text = l.Text(id, "Text", obj.Text)
+	obj.FunctionText = l.Text(id, "FunctionText", obj.FunctionText)
+	obj.Description = l.Text(id, "Description", obj.Description)
+
+	return obj
+}
+
+// ComObjectRef returns the communication object reference with translated texts.
+func (l *Localizer) ComObjectRef(ref ComObjectRef) ComObjectRef {
+	id := programElementID(ref.ManufacturerID, ref.ApplicationProgramID, string(ref.ModuleID), string(ref.ComObjectID), string(ref.ID))
+	ref.Text = l.Text(id, "Text", ref.Text)
+	ref.FunctionText = l.Text(id, "FunctionText", ref.FunctionText)
+	ref.Description = l.Text(id, "Description", ref.Description)
+
+	return ref
+}
+
+// Product returns the product with translated text.
+func (l *Localizer) Product(p Product) Product {
+	id := TranslationRefID(strings.Join([]string{string(p.ManufacturerID), string(p.HardwareID), string(p.ID)}, "_"))
+	p.Text = l.Text(id, "Text", p.Text)
+
+	return p
+}
+
+// Hardware returns the hardware of the manufacturer with translated name.
+func (l *Localizer) Hardware(m ManufacturerID, h Hardware) Hardware {
+	id := TranslationRefID(strings.Join([]string{string(m), string(h.ID)}, "_"))
+	h.Name = l.Text(id, "Name", h.Name)
+
+	return h
+}
+
+// CatalogSection returns the catalog section of the manufacturer with translated name.
+// Subsections and items are not translated.
+func (l *Localizer) CatalogSection(m ManufacturerID, s CatalogSection) CatalogSection {
+	s.Name = l.Text(TranslationRefID(strings.Join([]string{string(m), string(s.ID)}, "_")), "Name", s.Name)
+
+	return s
+}
+
+// CatalogItem returns the catalog item with translated name and description.
+func (l *Localizer) CatalogItem(item CatalogItem) CatalogItem {
+	id := TranslationRefID(strings.Join([]string{string(item.ManufacturerID), string(item.HardwareID), string(item.Hardware2ProgramID), string(item.ID)}, "_"))
+	item.Name = l.Text(id, "Name", item.Name)
+	item.VisibleDescription = l.Text(id, "VisibleDescription", item.VisibleDescription)
+
+	return item
+}
+
+// Parameter returns the parameter of the application program with translated texts
+// and enumeration texts.
+func (l *Localizer) Parameter(ap *ApplicationProgram, p Parameter) Parameter {
+	id := programElementID(ap.ManufacturerID, ap.ID, string(p.ID))
+	p.Text = l.Text(id, "Text", p.Text)
+	p.SuffixText = l.Text(id, "SuffixText", p.SuffixText)
+
+	enums := make([]ParameterEnumeration, len(p.Type.Enumerations))
+	for n, enum := range p.Type.Enumerations {
+		enums[n] = l.Enumeration(ap, enum)
+	}
+	p.Type.Enumerations = enums
+
+	return p
+}
+
+// ParameterRef returns the parameter reference of the application program with translated text.
+func (l *Localizer) ParameterRef(ap *ApplicationProgram, ref ParameterRef) ParameterRef {
+	ref.Text = l.Text(programElementID(ap.ManufacturerID, ap.ID, string(ref.ID)), "Text", ref.Text)
+
+	return ref
+}
+
+// Enumeration returns the enumeration of a parameter type of the application program with translated text.
+func (l *Localizer) Enumeration(ap *ApplicationProgram, enum ParameterEnumeration) ParameterEnumeration {
+	enum.Text = l.Text(programElementID(ap.ManufacturerID, ap.ID, enum.ID), "Text", enum.Text)
+
+	return enum
+}
+
+// programElementID returns the id of an element of an application program as used by translations,
+// e.g. "M-0083_A-0019-21-D29E_O-0".
+func programElementID(m ManufacturerID, ap ApplicationProgramID, ids ...string) TranslationRefID {
+	parts := []string{string(m), string(ap)}
+	for _, id := range ids {
+		if len(id) > 0 {
+			parts = append(parts, id)
+		}
+	}
+
+	return TranslationRefID(strings.Join(parts, "_"))
+}
+
+// Localized returns the effective communication object with texts in the languages
+// of the localizer. Placeholders are substituted as by Effective.
+func (o *ResolvedComObject) Localized(l *Localizer) EffectiveComObject {
+	var obj *ComObject
+	if o.Object != nil {
+		localized := l.ComObject(*o.Object)
+		obj = &localized
+	}
+
+	var ref *ComObjectRef
+	if o.Ref != nil {
+		localized := l.ComObjectRef(*o.Ref)
+		ref = &localized
+	}
+
+	return o.effective(obj, ref)
+}
+
+// Localizer decodes the manufacturer, hardware and catalog files of the archive and returns
+// a localizer for their translations in the preferred languages.
+func (ex *ExportArchive) Localizer(preferred ...LanguageID) (*Localizer, error) {
+	l := NewLocalizer(preferred)
+	for _, mf := range ex.ManufacturerFiles {
+		md, err := mf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		l.Add(md.Languages)
+	}
+
+	for _, hf := range ex.HardwareFiles {
+		hd, err := hf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		l.Add(hd.Languages)
+	}
+
+	for _, cf := range ex.CatalogFiles {
+		c, err := cf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		l.Add(c.Languages)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,47 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestLocalizer(t *testing.T) {
+	languages := []Language{
+		Language{ID: "de-DE", Translations: []Translation{
+			Translation{RefID: "M-0001_A-1_O-1", Texts: map[string]string{"Text": "Kanal A"}},
+		}},
+		Language{ID: "en-US", Translations: []Translation{
+			Translation{RefID: "M-0001_A-1_O-1", Texts: map[string]string{"Text": "Channel A", "FunctionText": "Switch"}},
+		}},
+	}
+
+	obj := ComObject{ID: "O-1", ManufacturerID: "M-0001", ApplicationProgramID: "A-1", Text: "Default", FunctionText: "Default"}
+
+	l := NewLocalizer([]LanguageID{"fr-FR", "de-DE", "en-US"}, languages)
+	localized := l.ComObject(obj)
+	if is, want := localized.Text, "Kanal A"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := localized.FunctionText, "Switch"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := NewLocalizer([]LanguageID{"fr-FR"}, languages).ComObject(obj).Text, "Default"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	if l, err = archive.Localizer("de-DE"); err != nil {
+		t.Fatal(err)
+	}
+
+	product := Product{ID: "P-AMS.2D1216.2E02", ManufacturerID: "M-0083", HardwareID: "H-4-2"}
+	if is, want := l.Product(product).Text, "AMS-1216.02 Schaltaktor mit Strommessung 12-fach, 12TE, 16A"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
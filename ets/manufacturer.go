@@ -2,7 +2,6 @@ package ets
 
 import (
 	"encoding/xml"
-	"fmt"
 	"io"
 )
 
@@ -15,19 +14,23 @@ type ComObject struct {
 	ApplicationProgramID ApplicationProgramID
 	ManufacturerID       ManufacturerID
 	ModuleID             ModuleID
+	ModuleInstanceID     ModuleInstanceID // set for instantiated communication objects of modules
 	Name                 string
+	Number               uint
+	BaseNumber           string // argument of the module which is added to the number
+	TextParameterRefID   ParameterRefID
 	Text                 string
 	Description          string
 	FunctionText         string
 	ObjectSize           string
 	DatapointType        string
 	Priority             string
-	ReadFlag             bool
-	WriteFlag            bool
-	CommunicationFlag    bool
-	TransmitFlag         bool
-	UpdateFlag           bool
-	ReadOnInitFlag       bool
+	ReadFlag             Flag
+	WriteFlag            Flag
+	CommunicationFlag    Flag
+	TransmitFlag         Flag
+	UpdateFlag           Flag
+	ReadOnInitFlag       Flag
 }
 
 // ComObjectRef is an instance/reference to a communication object.
@@ -36,19 +39,22 @@ type ComObjectRef struct {
 	ComObjectID          ComObjectID
 	ApplicationProgramID ApplicationProgramID
 	ManufacturerID       ManufacturerID
+	ModuleID             ModuleID
+	ModuleInstanceID     ModuleInstanceID // set for instantiated communication object references of modules
 	Name                 string
+	TextParameterRefID   ParameterRefID
 	Text                 string
 	Description          string
 	FunctionText         string
 	ObjectSize           string
 	DatapointType        string
 	Priority             string
-	ReadFlag             bool
-	WriteFlag            bool
-	CommunicationFlag    bool
-	TransmitFlag         bool
-	UpdateFlag           bool
-	ReadOnInitFlag       bool
+	ReadFlag             Flag
+	WriteFlag            Flag
+	CommunicationFlag    Flag
+	TransmitFlag         Flag
+	UpdateFlag           Flag
+	ReadOnInitFlag       Flag
 }
 
 // ApplicationProgramID is the ID of an application program.
@@ -56,14 +62,21 @@ type ApplicationProgramID string
 
 // ApplicationProgram is an application program.
 type ApplicationProgram struct {
-	ID             ApplicationProgramID
-	ManufacturerID ManufacturerID
-	Name           string
-	Version        uint
-	Objects        []ComObject
-	ObjectRefs     []ComObjectRef
+	ID              ApplicationProgramID
+	ManufacturerID  ManufacturerID
+	Name            string
+	Version         uint
+	Objects         []ComObject
+	ObjectRefs      []ComObjectRef
+	ParameterTypes  []ParameterType
+	Parameters      []Parameter
+	ParameterRefs   []ParameterRef
+	Dynamic         []DynamicNode // nil if the program has no Dynamic section
+	ModuleDefs      []ModuleDef
+	ModuleInstances []ModuleInstance
 }
 
+// ModuleID is the ID of a module definition.
 type ModuleID string
 
 // ManufacturerID is the ID of a manufacturer.
@@ -85,7 +98,7 @@ func (md *ManufacturerData) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 		return d.DecodeElement((*manufacturerData11)(md), &start)
 
 	default:
-		return fmt.Errorf("Unexpected namespace '%s'", ns)
+		return &UnsupportedSchemaError{Namespace: ns}
 	}
 }
 
@@ -0,0 +1,135 @@
+package ets
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// MasterManufacturer is a manufacturer registered in the master data.
+type MasterManufacturer struct {
+	ID                ManufacturerID
+	KnxManufacturerID uint
+	Name              string
+	DefaultLanguage   LanguageID
+}
+
+// MediumTypeID is the ID of a medium type, e.g. "MT-0".
+type MediumTypeID string
+
+// MediumType is a medium type, e.g. twisted pair.
+type MediumType struct {
+	ID     MediumTypeID
+	Number uint
+	Name   string // e.g. "TP"
+	Text   string // e.g. "Twisted Pair"
+}
+
+// MaskVersionID is the ID of a mask version, e.g. "MV-0701".
+type MaskVersionID string
+
+// MaskVersion is a mask version (device descriptor type 0) of a device.
+type MaskVersion struct {
+	ID              MaskVersionID
+	MaskVersion     uint
+	Name            string
+	ManagementModel string
+	MediumTypeID    MediumTypeID
+}
+
+// MasterData contains the KNX master data (knx_master.xml) of an archive.
+type MasterData struct {
+	Version          string
+	Manufacturers    []MasterManufacturer
+	DatapointTypes   *DatapointTypeRegistry
+	MediumTypes      []MediumType
+	MaskVersions     []MaskVersion
+	Languages        []Language
+	ProductLanguages []LanguageID
+}
+
+// Manufacturer returns the manufacturer with the given id.
+func (m *MasterData) Manufacturer(id ManufacturerID) (*MasterManufacturer, bool) {
+	for i := range m.Manufacturers {
+		if m.Manufacturers[i].ID == id {
+			return &m.Manufacturers[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// ManufacturerName returns the name of the manufacturer with the given id or
+// the id if the manufacturer is unknown.
+func (m *MasterData) ManufacturerName(id ManufacturerID) string {
+	if mf, ok := m.Manufacturer(id); ok {
+		return mf.Name
+	}
+
+	return string(id)
+}
+
+// MediumType returns the medium type with the given id.
+func (m *MasterData) MediumType(id MediumTypeID) (*MediumType, bool) {
+	for i := range m.MediumTypes {
+		if m.MediumTypes[i].ID == id {
+			return &m.MediumTypes[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// MaskVersion returns the mask version with the given id.
+func (m *MasterData) MaskVersion(id MaskVersionID) (*MaskVersion, bool) {
+	for i := range m.MaskVersions {
+		if m.MaskVersions[i].ID == id {
+			return &m.MaskVersions[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (m *MasterData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Decide which schema to use based on the value of the 'xmlns' attribute.
+	ns := getNamespace(start)
+	switch ns {
+	case schema11Namespace, schema12Namespace, schema13Namespace, schema14Namespace, schema20Namespace, schema21Namespace, schema22Namespace, schema23Namespace:
+		return d.DecodeElement((*masterData11)(m), &start)
+
+	default:
+		return &UnsupportedSchemaError{Namespace: ns}
+	}
+}
+
+// DecodeMasterData parses the contents of a master data file (knx_master.xml).
+func DecodeMasterData(r io.Reader) (*MasterData, error) {
+	m := &MasterData{}
+	if err := xml.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// MasterFile is the master data file of an archive.
+type MasterFile struct {
+	Path string
+
+	archive *archive
+}
+
+// Decode the file in order to retrieve the master data inside it.
+func (mf *MasterFile) Decode() (m *MasterData, err error) {
+	r, err := mf.archive.open(mf.Path)
+	if err != nil {
+		return
+	}
+
+	m, err = DecodeMasterData(r)
+	r.Close()
+	err = decodeError(mf.Path, err)
+
+	return
+}
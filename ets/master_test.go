@@ -0,0 +1,48 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestMasterData(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	if archive.MasterFile == nil {
+		t.Fatal("Master data file not found")
+	}
+
+	m, err := archive.MasterFile.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := m.ManufacturerName("M-0083"), "MDT technologies"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	mask, ok := m.MaskVersion("MV-0701")
+	if !ok {
+		t.Fatal("Mask version MV-0701 not found")
+	}
+
+	medium, ok := m.MediumType(mask.MediumTypeID)
+	if !ok {
+		t.Fatalf("Medium type %s not found", mask.MediumTypeID)
+	}
+
+	if is, want := medium.Name, "TP"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if _, ok := m.DatapointTypes.Lookup(DatapointType{Main: 9, Sub: 1}); !ok {
+		t.Fatal("Datapoint type 9.001 not found")
+	}
+
+	if len(m.ProductLanguages) == 0 {
+		t.Fatal("Missing product languages")
+	}
+}
@@ -0,0 +1,226 @@
+package ets
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ModuleInstanceID is the ID of a module instance relative to its application program, e.g. "MD-1_M-1".
+type ModuleInstanceID string
+
+// ModuleArgument is an argument of a module definition.
+type ModuleArgument struct {
+	ID   string // e.g. "MD-1_A-1"
+	Name string
+}
+
+// ModuleDef is a module definition. Its communication objects are templates which
+// are instantiated for every module instance.
+type ModuleDef struct {
+	ID         ModuleID
+	Name       string
+	Arguments  []ModuleArgument
+	Objects    []ComObject
+	ObjectRefs []ComObjectRef
+	Dynamic    []DynamicNode
+}
+
+// ModuleArgumentValue is the value of an argument of a module instance.
+type ModuleArgumentValue struct {
+	ArgumentID string // e.g. "MD-1_A-1"
+	Value      string
+}
+
+// ModuleInstance is an instance of a module definition.
+type ModuleInstance struct {
+	ID          ModuleInstanceID
+	ModuleDefID ModuleID
+	Arguments   []ModuleArgumentValue
+}
+
+// ModuleDef returns the module definition with the given id.
+func (ap *ApplicationProgram) ModuleDef(id ModuleID) (*ModuleDef, bool) {
+	for i := range ap.ModuleDefs {
+		if ap.ModuleDefs[i].ID == id {
+			return &ap.ModuleDefs[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// ArgumentValues returns the argument values of the module instance by their argument names.
+func (md ModuleDef) ArgumentValues(mi ModuleInstance) map[string]string {
+	names := make(map[string]string, len(md.Arguments))
+	for _, arg := range md.Arguments {
+		names[arg.ID] = arg.Name
+	}
+
+	args := make(map[string]string, len(mi.Arguments))
+	for _, arg := range mi.Arguments {
+		if name, ok := names[arg.ArgumentID]; ok {
+			args[name] = arg.Value
+		}
+	}
+
+	return args
+}
+
+// InstanceValues returns the parameter values for the Dynamic section of the module definition.
+// The values of the module's parameter references, e.g. "MD-1_P-1_R-1", are replaced by the
+// values configured for the module instance, e.g. "MD-1_M-1_P-1_R-1". The argument values of
+// the module instance are added by their argument ids, e.g. "MD-1_A-1".
+func (md ModuleDef) InstanceValues(mi ModuleInstance, values map[ParameterRefID]string) map[ParameterRefID]string {
+	res := make(map[ParameterRefID]string, len(values)+len(mi.Arguments))
+	for id, v := range values {
+		res[id] = v
+	}
+
+	prefix := string(md.ID) + "_"
+	for id := range values {
+		if !strings.HasPrefix(string(id), prefix) {
+			continue
+		}
+
+		if v, ok := values[ParameterRefID(string(mi.ID)+"_"+strings.TrimPrefix(string(id), prefix))]; ok {
+			res[id] = v
+		}
+	}
+
+	for _, arg := range mi.Arguments {
+		res[ParameterRefID(arg.ArgumentID)] = arg.Value
+	}
+
+	return res
+}
+
+// Instantiate returns the communication objects and references of the module definition
+// for the module instance. Argument placeholders in the texts are substituted and the
+// object numbers are offset by the value of their BaseNumber argument.
+func (md ModuleDef) Instantiate(mi ModuleInstance) ([]ComObject, []ComObjectRef) {
+	args := md.ArgumentValues(mi)
+	values := make(map[string]string, len(mi.Arguments))
+	for _, arg := range mi.Arguments {
+		values[arg.ArgumentID] = arg.Value
+	}
+
+	objs := make([]ComObject, len(md.Objects))
+	for n, obj := range md.Objects {
+		obj.ModuleInstanceID = mi.ID
+		obj.Text = substituteArguments(obj.Text, args)
+		obj.FunctionText = substituteArguments(obj.FunctionText, args)
+		if base, err := strconv.ParseUint(values[obj.BaseNumber], 10, 32); err == nil {
+			obj.Number += uint(base)
+		}
+		objs[n] = obj
+	}
+
+	refs := make([]ComObjectRef, len(md.ObjectRefs))
+	for n, ref := range md.ObjectRefs {
+		ref.ModuleInstanceID = mi.ID
+		ref.Text = substituteArguments(ref.Text, args)
+		ref.FunctionText = substituteArguments(ref.FunctionText, args)
+		refs[n] = ref
+	}
+
+	return objs, refs
+}
+
+// instantiateModules returns the communication objects and references of the module instances.
+func (ap *ApplicationProgram) instantiateModules(instances []ModuleInstance) ([]ComObject, []ComObjectRef) {
+	var objs []ComObject
+	var refs []ComObjectRef
+	for _, mi := range instances {
+		if md, ok := ap.ModuleDef(mi.ModuleDefID); ok {
+			o, r := md.Instantiate(mi)
+			objs = append(objs, o...)
+			refs = append(refs, r...)
+		}
+	}
+
+	return objs, refs
+}
+
+// moduleInstances returns the module instances of the Module elements of a Dynamic section.
+func moduleInstances(nodes []DynamicNode) []ModuleInstance {
+	var instances []ModuleInstance
+	for _, n := range nodes {
+		if n.Kind != "Module" {
+			instances = append(instances, moduleInstances(n.Children)...)
+			continue
+		}
+
+		mi := ModuleInstance{
+			ID:          ModuleInstanceID(n.ID),
+			ModuleDefID: ModuleID(n.RefID),
+		}
+		for _, arg := range n.Children {
+			mi.Arguments = append(mi.Arguments, ModuleArgumentValue{
+				ArgumentID: arg.RefID,
+				Value:      arg.Value,
+			})
+		}
+		instances = append(instances, mi)
+	}
+
+	return instances
+}
+
+// moduleInstanceID returns the module instance of a communication object
+// instance reference id, e.g. "MD-1_M-1" for "MD-1_M-1_O-3-1_R-1".
+func moduleInstanceID(refID string) ModuleInstanceID {
+	var ids []string
+	for _, id := range strings.Split(programRelativeID(refID), "_") {
+		if strings.HasPrefix(id, "O-") || strings.HasPrefix(id, "R-") {
+			break
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 || !strings.HasPrefix(ids[0], "MD-") {
+		return ""
+	}
+
+	return ModuleInstanceID(strings.Join(ids, "_"))
+}
+
+// substituteArguments replaces the placeholders {{name}} and {{name:default}}
+// with the values of the arguments.
+func substituteArguments(s string, args map[string]string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "{{")
+		end := -1
+		if start >= 0 {
+			end = strings.Index(s[start:], "}}")
+		}
+
+		if end < 0 {
+			b.WriteString(s)
+			break
+		}
+		end += start
+
+		b.WriteString(s[:start])
+		name := s[start+2 : end]
+		def := ""
+		if i := strings.Index(name, ":"); i >= 0 {
+			name, def = name[:i], name[i+1:]
+		}
+
+		if v, ok := args[strings.TrimSpace(name)]; ok {
+			b.WriteString(v)
+		} else if len(def) > 0 {
+			b.WriteString(def)
+		} else {
+			b.WriteString(s[start : end+2])
+		}
+		s = s[end+2:]
+	}
+
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package ets
+
+import (
+	"strings"
+	"testing"
+)
+
+const testModuleData = `<?xml version="1.0" encoding="utf-8"?>
+<KNX xmlns="http://knx.org/xml/project/20">
+  <ManufacturerData>
+    <Manufacturer RefId="M-00FA">
+      <ApplicationPrograms>
+        <ApplicationProgram Id="M-00FA_A-0001-01-0001" Name="Modules" ApplicationVersion="1">
+          <Static>
+            <ComObjectTable>
+              <ComObject Id="M-00FA_A-0001-01-0001_O-1" Name="Central" Number="1" Text="Central" ObjectSize="1 Bit" />
+            </ComObjectTable>
+            <ComObjectRefs>
+              <ComObjectRef Id="M-00FA_A-0001-01-0001_O-1_R-1" RefId="M-00FA_A-0001-01-0001_O-1" />
+            </ComObjectRefs>
+          </Static>
+          <ModuleDefs>
+            <ModuleDef Id="M-00FA_A-0001-01-0001_MD-1" Name="Channel">
+              <Arguments>
+                <Argument Id="M-00FA_A-0001-01-0001_MD-1_A-1" Name="argChannel" />
+                <Argument Id="M-00FA_A-0001-01-0001_MD-1_A-2" Name="argBase" />
+              </Arguments>
+              <Static>
+                <ComObjects>
+                  <ComObject Id="M-00FA_A-0001-01-0001_MD-1_O-3-1" Name="Switch" Number="1" BaseNumber="M-00FA_A-0001-01-0001_MD-1_A-2" Text="{{argChannel}}: Switch" ObjectSize="1 Bit" />
+                </ComObjects>
+                <ComObjectRefs>
+                  <ComObjectRef Id="M-00FA_A-0001-01-0001_MD-1_O-3-1_R-1" RefId="M-00FA_A-0001-01-0001_MD-1_O-3-1" />
+                </ComObjectRefs>
+                <Parameters>
+                  <Parameter Id="M-00FA_A-0001-01-0001_MD-1_P-1" Name="Enabled" ParameterType="M-00FA_A-0001-01-0001_PT-Bool" Text="Enabled" Value="1" />
+                </Parameters>
+                <ParameterRefs>
+                  <ParameterRef Id="M-00FA_A-0001-01-0001_MD-1_P-1_R-1" RefId="M-00FA_A-0001-01-0001_MD-1_P-1" />
+                </ParameterRefs>
+              </Static>
+              <Dynamic>
+                <Channel Id="M-00FA_A-0001-01-0001_MD-1_CH-1" Text="{{argChannel}}">
+                  <ParameterBlock Id="M-00FA_A-0001-01-0001_MD-1_PB-1" Text="General">
+                    <ParameterRefRef RefId="M-00FA_A-0001-01-0001_MD-1_P-1_R-1" />
+                    <choose ParamRefId="M-00FA_A-0001-01-0001_MD-1_P-1_R-1">
+                      <when test="1">
+                        <ComObjectRefRef RefId="M-00FA_A-0001-01-0001_MD-1_O-3-1_R-1" />
+                      </when>
+                    </choose>
+                  </ParameterBlock>
+                </Channel>
+              </Dynamic>
+            </ModuleDef>
+          </ModuleDefs>
+          <Dynamic>
+            <ChannelIndependentBlock>
+              <Module Id="M-00FA_A-0001-01-0001_MD-1_M-1" RefId="M-00FA_A-0001-01-0001_MD-1">
+                <TextArg RefId="M-00FA_A-0001-01-0001_MD-1_A-1" Value="Channel A" />
+                <NumericArg RefId="M-00FA_A-0001-01-0001_MD-1_A-2" Value="10" />
+              </Module>
+              <Module Id="M-00FA_A-0001-01-0001_MD-1_M-2" RefId="M-00FA_A-0001-01-0001_MD-1">
+                <TextArg RefId="M-00FA_A-0001-01-0001_MD-1_A-1" Value="Channel B" />
+                <NumericArg RefId="M-00FA_A-0001-01-0001_MD-1_A-2" Value="20" />
+              </Module>
+            </ChannelIndependentBlock>
+          </Dynamic>
+        </ApplicationProgram>
+      </ApplicationPrograms>
+    </Manufacturer>
+  </ManufacturerData>
+</KNX>`
+
+func TestModuleInstances(t *testing.T) {
+	md, err := DecodeManufacturerData(strings.NewReader(testModuleData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ap := md.Programs[0]
+	if is, want := len(ap.ModuleInstances), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(ap.Objects), 3; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	idx := newComObjectIndex(&ap)
+	ref, obj := idx.lookup("O-3-1", "R-1", "MD-1_M-2")
+	if ref == nil || obj == nil {
+		t.Fatal("Communication object of module instance not found")
+	}
+
+	if is, want := obj.Text, "Channel B: Switch"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := obj.Number, uint(21); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if _, obj := idx.lookup("O-1", "R-1", ""); obj == nil || obj.Name != "Central" {
+		t.Fatalf("Unexpected communication object %v", obj)
+	}
+
+	if is, want := moduleInstanceID("MD-1_M-2_O-3-1_R-1"), ModuleInstanceID("MD-1_M-2"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(ap.ActiveObjectRefs(ap.ParameterValues(nil))), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	// The object of the second module instance is hidden by the choose element of the module.
+	di := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			{RefID: "MD-1_M-2_P-1_R-1", Value: "0"},
+		},
+	}
+	refs := ap.ActiveObjectRefs(ap.ParameterValues(di))
+	if is, want := len(refs), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := refs[0].ModuleInstanceID, ModuleInstanceID("MD-1_M-1"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
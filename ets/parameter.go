@@ -0,0 +1,190 @@
+package ets
+
+import (
+	"strings"
+)
+
+// ParameterTypeID is the ID of a parameter type relative to its application program, e.g. "PT-en.5FonOffDelay".
+type ParameterTypeID string
+
+// ParameterTypeKind is the kind of values of a parameter type.
+type ParameterTypeKind int
+
+const (
+	// ParameterTypeOther is a type which is not decoded (e.g. TypeIPAddress or TypePicture).
+	ParameterTypeOther ParameterTypeKind = iota
+	// ParameterTypeNone is a type without a value, used for informational parameters.
+	ParameterTypeNone
+	// ParameterTypeNumber is an integer number within a range.
+	ParameterTypeNumber
+	// ParameterTypeEnum is one of a list of enumerated values.
+	ParameterTypeEnum
+	// ParameterTypeText is a text with a maximum size.
+	ParameterTypeText
+	// ParameterTypeFloat is a floating point number within a range.
+	ParameterTypeFloat
+	// ParameterTypeTime is a duration within a range.
+	ParameterTypeTime
+)
+
+func (k ParameterTypeKind) String() string {
+	switch k {
+	case ParameterTypeNone:
+		return "none"
+	case ParameterTypeNumber:
+		return "number"
+	case ParameterTypeEnum:
+		return "enum"
+	case ParameterTypeText:
+		return "text"
+	case ParameterTypeFloat:
+		return "float"
+	case ParameterTypeTime:
+		return "time"
+	default:
+		return "other"
+	}
+}
+
+// ParameterEnumeration is a value of an enumerated parameter type.
+type ParameterEnumeration struct {
+	ID    string
+	Text  string
+	Value string
+}
+
+// ParameterType describes the values of a parameter.
+type ParameterType struct {
+	ID           ParameterTypeID
+	Name         string
+	Kind         ParameterTypeKind
+	SizeInBit    int
+	NumberType   string // e.g. "unsignedInt" or "signedInt" for number types
+	Unit         string // e.g. "Seconds" for time types
+	Min          *float64
+	Max          *float64
+	Enumerations []ParameterEnumeration
+}
+
+// Enumeration returns the enumeration with the given value.
+func (pt ParameterType) Enumeration(value string) (*ParameterEnumeration, bool) {
+	for i := range pt.Enumerations {
+		if pt.Enumerations[i].Value == value {
+			return &pt.Enumerations[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// ParameterID is the ID of a parameter relative to its application program, e.g. "P-1" or "UP-5028".
+type ParameterID string
+
+// Parameter is a parameter of an application program.
+type Parameter struct {
+	ID         ParameterID
+	Name       string
+	Text       string
+	SuffixText string
+	Access     string // "None", "Read" or "ReadWrite"; empty means "ReadWrite"
+	Value      string // default value
+	Type       ParameterType
+}
+
+// ParameterRefID is the ID of a parameter reference relative to its application program, e.g. "P-1_R-1".
+type ParameterRefID string
+
+// ParameterRef is a reference to a parameter, which may override its text, access and default value.
+type ParameterRef struct {
+	ID          ParameterRefID
+	ParameterID ParameterID
+	Text        string
+	Access      string
+	Value       string
+}
+
+// ParameterInstanceRef is the configured value of a parameter of a device.
+type ParameterInstanceRef struct {
+	RefID ParameterRefID
+	Value string
+}
+
+// Parameter returns the parameter with the given id.
+func (ap *ApplicationProgram) Parameter(id ParameterID) (*Parameter, bool) {
+	for i := range ap.Parameters {
+		if ap.Parameters[i].ID == id {
+			return &ap.Parameters[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// ParameterValues returns the values of all parameter references of the application program
+// for a device. All references of a parameter share the value configured in the device, otherwise
+// the default of the reference or its parameter is used. The device may be nil to get the
+// default values only.
+func (ap *ApplicationProgram) ParameterValues(di *DeviceInstance) map[ParameterRefID]string {
+	params := make(map[ParameterID]*Parameter, len(ap.Parameters))
+	for i := range ap.Parameters {
+		params[ap.Parameters[i].ID] = &ap.Parameters[i]
+	}
+
+	refs := make(map[ParameterRefID]*ParameterRef, len(ap.ParameterRefs))
+	for i := range ap.ParameterRefs {
+		refs[ap.ParameterRefs[i].ID] = &ap.ParameterRefs[i]
+	}
+
+	configured := map[ParameterID]string{}
+	if di != nil {
+		for _, inst := range di.Parameters {
+			if ref, ok := refs[inst.RefID]; ok {
+				configured[ref.ParameterID] = inst.Value
+			}
+		}
+	}
+
+	values := make(map[ParameterRefID]string, len(ap.ParameterRefs))
+	for _, ref := range ap.ParameterRefs {
+		if v, ok := configured[ref.ParameterID]; ok {
+			values[ref.ID] = v
+		} else if len(ref.Value) > 0 {
+			values[ref.ID] = ref.Value
+		} else if p, ok := params[ref.ParameterID]; ok {
+			values[ref.ID] = p.Value
+		}
+	}
+
+	if di != nil {
+		for _, inst := range di.Parameters {
+			values[inst.RefID] = inst.Value
+		}
+	}
+
+	return values
+}
+
+// IsParameterVisible returns false if the parameter reference or its parameter
+// is not accessible (Access="None").
+func (ap *ApplicationProgram) IsParameterVisible(ref ParameterRef) bool {
+	if len(ref.Access) > 0 {
+		return ref.Access != "None"
+	}
+
+	if p, ok := ap.Parameter(ref.ParameterID); ok {
+		return p.Access != "None"
+	}
+
+	return true
+}
+
+// programRelativeID returns the id without the leading manufacturer and application program ids,
+// e.g. "M-0083_A-0019-21-D29E_P-1_R-1" becomes "P-1_R-1".
+func programRelativeID(s string) string {
+	ids := strings.Split(s, "_")
+	for len(ids) > 1 && (strings.HasPrefix(ids[0], "M-") || strings.HasPrefix(ids[0], "A-")) {
+		ids = ids[1:]
+	}
+
+	return strings.Join(ids, "_")
+}
@@ -0,0 +1,78 @@
+package ets
+
+import (
+	"testing"
+)
+
+func decodeTestProgram(t *testing.T, id ApplicationProgramID) ApplicationProgram {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	for _, mf := range archive.ManufacturerFiles {
+		md, err := mf.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, ap := range md.Programs {
+			if ap.ID == id {
+				return ap
+			}
+		}
+	}
+
+	t.Fatalf("Application program %s not found", id)
+	return ApplicationProgram{}
+}
+
+func TestParameters(t *testing.T) {
+	ap := decodeTestProgram(t, "A-0019-21-D29E")
+
+	p, ok := ap.Parameter("P-5012")
+	if !ok {
+		t.Fatal("Parameter P-5012 not found")
+	}
+
+	if is, want := p.Text, "Time for Staircase lighting"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := p.Type.Kind, ParameterTypeNumber; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if p.Type.Max == nil || *p.Type.Max != 30000 {
+		t.Fatalf("Unexpected maximum %v", p.Type.Max)
+	}
+
+	union, ok := ap.Parameter("UP-5028")
+	if !ok {
+		t.Fatal("Union parameter UP-5028 not found")
+	}
+
+	if is, want := union.Type.Kind, ParameterTypeEnum; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if _, ok := union.Type.Enumeration(union.Value); !ok {
+		t.Fatalf("Default value %s is not enumerated", union.Value)
+	}
+
+	di := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			ParameterInstanceRef{RefID: "P-1_R-1000", Value: "0"},
+		},
+	}
+
+	values := ap.ParameterValues(di)
+	if is, want := values["P-1_R-1000"], "0"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := ap.ParameterValues(nil)["P-1_R-1000"], "1"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
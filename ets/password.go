@@ -0,0 +1,113 @@
+package ets
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/yeka/zip"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	masterFileName = "knx_master.xml"
+
+	// ets6PasswordSalt is the salt used by ETS6 to derive the
+	// password of the project archive from the user password.
+	ets6PasswordSalt       = "21.project.ets.knx.org"
+	ets6PasswordIterations = 65536
+	ets6PasswordKeyLen     = 32
+
+	// ets6Schema is the first schema version used by ETS6.
+	ets6Schema = 21
+	// projectNamespacePrefix is the prefix of the versioned project namespaces.
+	projectNamespacePrefix = "http://knx.org/xml/project/"
+)
+
+// archivePasswords returns the candidates for the password of the encrypted files
+// in an archive whose files use the namespace ns. For ETS6 archives the password
+// derived from the user password is tried first and the password as given second,
+// because callers may already pass the derived password.
+func archivePasswords(password, ns string) []string {
+	if v, ok := schemaVersion(ns); ok && v >= ets6Schema {
+		if derived := ets6Password(password); derived != password {
+			return []string{derived, password}
+		}
+	}
+
+	return []string{password}
+}
+
+// schemaVersion returns the version of the project namespace ns,
+// e.g. 21 for "http://knx.org/xml/project/21".
+func schemaVersion(ns string) (int, bool) {
+	if !strings.HasPrefix(ns, projectNamespacePrefix) {
+		return 0, false
+	}
+
+	v, err := strconv.Atoi(strings.TrimPrefix(ns, projectNamespacePrefix))
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
+// ets6Password returns the archive password for the user password.
+// ETS6 derives the password with PBKDF2-HMAC-SHA256 from the UTF-16LE
+// encoded user password and encodes the key with base64.
+func ets6Password(password string) string {
+	if len(password) == 0 {
+		return ""
+	}
+
+	codes := utf16.Encode([]rune(password))
+	b := make([]byte, 2*len(codes))
+	for i, c := range codes {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+
+	key := pbkdf2.Key(b, []byte(ets6PasswordSalt), ets6PasswordIterations, ets6PasswordKeyLen, sha256.New)
+
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// archiveNamespace returns the namespace of the master data file in the archive.
+// An empty string is returned if the archive doesn't contain a master data file.
+func archiveNamespace(zr *zip.Reader) (string, error) {
+	for _, f := range zr.File {
+		if f.Name != masterFileName || f.IsEncrypted() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+
+		return documentNamespace(rc)
+	}
+
+	return "", nil
+}
+
+// documentNamespace returns the namespace of the root element of a xml document.
+func documentNamespace(r io.Reader) (string, error) {
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			return getNamespace(start), nil
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package ets
+
+// DefaultPowerSupplyCurrent is the rated current in mA of a standard KNX power supply.
+// The hardware data does not contain the rated current of power supplies.
+const DefaultPowerSupplyCurrent = 640.0
+
+// PowerSupplyCurrent returns the rated current in mA of the power supply hardware,
+// or 0 if it is unknown.
+type PowerSupplyCurrent func(hw *Hardware) float64
+
+// LinePowerBudget is the bus power budget of a line or, if the line has segments, of a segment.
+type LinePowerBudget struct {
+	Installation  *Installation
+	Area          *Area
+	Line          *Line
+	Segment       *Segment // nil if the line has no segments
+	Devices       []*ResolvedDevice
+	PowerSupplies []*ResolvedDevice
+	Unknown       []*ResolvedDevice // devices whose hardware is unknown and which are not part of the consumption
+	Consumption   float64           // bus current of the devices in mA
+	Supply        float64           // rated current of the power supplies in mA
+}
+
+// Margin returns the remaining current of the line or segment in mA, which is negative if the line is overloaded.
+func (b *LinePowerBudget) Margin() float64 {
+	return b.Supply - b.Consumption
+}
+
+// Overloaded returns true if the devices of the line or segment consume more current than the power supplies provide.
+func (b *LinePowerBudget) Overloaded() bool {
+	return b.Consumption > b.Supply
+}
+
+// PowerBudget is the bus power budget of the lines and segments of a project.
+type PowerBudget struct {
+	Lines []*LinePowerBudget
+}
+
+// Overloaded returns the budgets of the overloaded lines and segments.
+func (pb *PowerBudget) Overloaded() []*LinePowerBudget {
+	var lines []*LinePowerBudget
+	for _, b := range pb.Lines {
+		if b.Overloaded() {
+			lines = append(lines, b)
+		}
+	}
+
+	return lines
+}
+
+// PowerBudget sums the bus current of the devices of every line and compares it
+// against the power supplies of the line. Lines with segments are budgeted per segment.
+// The rated current of a power supply is returned by supplyCurrent. If supplyCurrent is nil
+// or doesn't know the power supply, DefaultPowerSupplyCurrent is used.
+func (rp *ResolvedProject) PowerBudget(supplyCurrent PowerSupplyCurrent) *PowerBudget {
+	type budgetKey struct {
+		line    *Line
+		segment *Segment
+	}
+
+	budgets := map[budgetKey]*LinePowerBudget{}
+	pb := &PowerBudget{}
+	for i := range rp.Installations {
+		inst := &rp.Installations[i]
+		for j := range inst.Topology {
+			area := &inst.Topology[j]
+			for k := range area.Lines {
+				line := &area.Lines[k]
+				if len(line.Segments) == 0 {
+					b := &LinePowerBudget{Installation: inst, Area: area, Line: line}
+					budgets[budgetKey{line, nil}] = b
+					pb.Lines = append(pb.Lines, b)
+					continue
+				}
+
+				for l := range line.Segments {
+					b := &LinePowerBudget{Installation: inst, Area: area, Line: line, Segment: &line.Segments[l]}
+					budgets[budgetKey{line, b.Segment}] = b
+					pb.Lines = append(pb.Lines, b)
+				}
+			}
+		}
+	}
+
+	for _, dev := range rp.Devices {
+		b, ok := budgets[budgetKey{dev.Line, dev.Segment}]
+		if !ok {
+			continue
+		}
+
+		b.Devices = append(b.Devices, dev)
+		switch {
+		case dev.Hardware == nil:
+			b.Unknown = append(b.Unknown, dev)
+		case dev.Hardware.IsPowerSupply:
+			b.PowerSupplies = append(b.PowerSupplies, dev)
+			b.Supply += supplyCurrent.of(dev.Hardware)
+		default:
+			b.Consumption += dev.Hardware.BusCurrent
+		}
+	}
+
+	return pb
+}
+
+func (fn PowerSupplyCurrent) of(hw *Hardware) float64 {
+	if fn != nil {
+		if current := fn(hw); current > 0 {
+			return current
+		}
+	}
+
+	return DefaultPowerSupplyCurrent
+}
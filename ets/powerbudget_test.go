@@ -0,0 +1,70 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestPowerBudget(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	projects, err := archive.ResolveProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pb := projects[0].PowerBudget(nil)
+
+	var consumption float64
+	var devices int
+	for _, b := range pb.Lines {
+		consumption += b.Consumption
+		devices += len(b.Devices)
+	}
+
+	if is, want := devices, 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	// The project contains no power supply.
+	if is, want := consumption, 10.0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	overloaded := pb.Overloaded()
+	if is, want := len(overloaded), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := overloaded[0].Margin(), -10.0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	// Power supplies with different rated currents on the same line.
+	currents := map[HardwareID]float64{"H-PS-160": 160}
+	for _, dev := range projects[0].Devices {
+		dev.Hardware = &Hardware{ID: HardwareID("H-PS-" + string(dev.ID)), IsPowerSupply: true}
+	}
+	projects[0].Devices[0].Hardware.ID = "H-PS-160"
+
+	pb = projects[0].PowerBudget(func(hw *Hardware) float64 {
+		return currents[hw.ID]
+	})
+
+	overloaded = pb.Overloaded()
+	if is, want := len(overloaded), 0; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	var supply float64
+	for _, b := range pb.Lines {
+		supply += b.Supply
+	}
+
+	if is, want := supply, 160.0+DefaultPowerSupplyCurrent; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
@@ -2,7 +2,6 @@ package ets
 
 import (
 	"encoding/xml"
-	"fmt"
 	"io"
 	"log"
 	"strings"
@@ -21,6 +20,7 @@ func getNamespace(start xml.StartElement) string {
 // ProjectID is a project identifier.
 type ProjectID string
 
+// GroupAddressStyle is the notation used for group addresses in a project.
 type GroupAddressStyle int
 
 const (
@@ -47,7 +47,7 @@ func (pi *ProjectInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) erro
 		return d.DecodeElement((*projectInfo11)(pi), &start)
 
 	default:
-		return fmt.Errorf("Unexpected namespace '%s'", ns)
+		return &UnsupportedSchemaError{Namespace: ns}
 	}
 }
 
@@ -61,9 +61,11 @@ func DecodeProjectInfo(r io.Reader) (*ProjectInfo, error) {
 	return info, nil
 }
 
-// Connector is a connection to a group address.
+// Connector is a connection to a group address. A communication object sends
+// telegrams to the group address of its sending connector and receives telegrams
+// from the group addresses of all its connectors.
 type Connector struct {
-	Receive bool
+	Receive bool // false for the sending connector
 	RefID   GroupAddressID
 }
 
@@ -96,14 +98,30 @@ func (s ComObjectRefID) IDs() (m string, ap string, o string, r string) {
 type ComObjectInstanceRef struct {
 	ComObjectRefID    ComObjectRefID
 	ComObjectID       ComObjectID
+	ModuleInstanceID  ModuleInstanceID
+	Text              string
+	FunctionText      string
 	DatapointType     string
+	Priority          string
 	Links             []string
-	ReadFlag          bool
-	WriteFlag         bool
-	CommunicationFlag bool
-	TransmitFlag      bool
-	UpdateFlag        bool
-	ReadOnInitFlag    bool
+	Connectors        []Connector // in the order of the project file, the sending connector first
+	ReadFlag          Flag
+	WriteFlag         Flag
+	CommunicationFlag Flag
+	TransmitFlag      Flag
+	UpdateFlag        Flag
+	ReadOnInitFlag    Flag
+}
+
+// SendingGroupAddress returns the group address of the sending connector.
+func (ref *ComObjectInstanceRef) SendingGroupAddress() (GroupAddressID, bool) {
+	for _, c := range ref.Connectors {
+		if !c.Receive {
+			return c.RefID, true
+		}
+	}
+
+	return "", false
 }
 
 // DeviceInstanceID is the ID of a device instance.
@@ -119,7 +137,12 @@ type DeviceInstance struct {
 	Hardware2ProgramID Hardware2ProgramID
 	Name               string
 	Address            uint16
+	IndividualAddress  *IndividualAddress // nil if the device has no address assigned
 	ComObjects         []ComObjectInstanceRef
+	Parameters         []ParameterInstanceRef
+	ModuleInstances    []ModuleInstance
+
+	hasAddress bool // true if the device has an address on its line
 }
 
 // LineID is the ID of a line.
@@ -127,11 +150,42 @@ type LineID string
 
 // Line is a line.
 type Line struct {
-	ID        LineID
-	ProjectID ProjectID
-	Name      string
-	Address   uint16
-	Devices   []DeviceInstance
+	ID                LineID
+	ProjectID         ProjectID
+	Name              string
+	Address           uint16
+	IndividualAddress IndividualAddress
+	Devices           []DeviceInstance // devices of all segments
+	Segments          []Segment        // nil for projects before ETS6
+}
+
+// Segment returns the segment which contains the device with the given id.
+// The result is nil if the line has no segments or the device is not part of the line.
+func (l *Line) Segment(id DeviceInstanceID) *Segment {
+	for i := range l.Segments {
+		s := &l.Segments[i]
+		for _, d := range s.Devices {
+			if d.ID == id {
+				return s
+			}
+		}
+	}
+
+	return nil
+}
+
+// SegmentID is the ID of a segment.
+type SegmentID string
+
+// Segment is a segment of a line, e.g. a TP segment behind a repeater or an RF segment.
+type Segment struct {
+	ID            SegmentID
+	ProjectID     ProjectID
+	Name          string
+	Number        int
+	MediumTypeID  MediumTypeID
+	DomainAddress string           // domain address of RF and PL segments
+	Devices       []DeviceInstance // shares the storage of the line's devices
 }
 
 // AreaID is the ID of an area.
@@ -139,11 +193,12 @@ type AreaID string
 
 // Area is an area.
 type Area struct {
-	ID        AreaID
-	ProjectID ProjectID
-	Name      string
-	Address   uint16
-	Lines     []Line
+	ID                AreaID
+	ProjectID         ProjectID
+	Name              string
+	Address           uint16
+	IndividualAddress IndividualAddress
+	Lines             []Line
 }
 
 // GroupAddressID is the ID of a group address.
@@ -155,7 +210,7 @@ type GroupAddress struct {
 	ProjectID     ProjectID
 	Name          string
 	Description   string
-	Address       uint16
+	Address       GroupAddressValue
 	DatapointType string
 }
 
@@ -166,8 +221,8 @@ type GroupRangeID string
 type GroupRange struct {
 	ID         GroupRangeID
 	Name       string
-	RangeStart uint16
-	RangeEnd   uint16
+	RangeStart GroupAddressValue
+	RangeEnd   GroupAddressValue
 	Addresses  []GroupAddress
 	SubRanges  []GroupRange
 }
@@ -197,10 +252,11 @@ type Space struct {
 
 // Installation is an installation within a project.
 type Installation struct {
-	Name           string
-	Topology       []Area
-	Locations      []Space
-	GroupAddresses []GroupRange
+	Name              string
+	Topology          []Area
+	UnassignedDevices []DeviceInstance // devices which are not part of a line and have no individual address
+	Locations         []Space
+	GroupAddresses    []GroupRange
 }
 
 // Project contains an entire project. These information are usually stored within a file located
@@ -227,7 +283,7 @@ func (p *Project) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		return d.DecodeElement((*project21)(p), &start)
 
 	default:
-		return fmt.Errorf("Unexpected namespace '%s'", ns)
+		return &UnsupportedSchemaError{Namespace: ns}
 	}
 }
 
@@ -0,0 +1,401 @@
+package ets
+
+// ResolvedProject is a project whose devices, application programs,
+// communication objects and group addresses are linked with each other.
+type ResolvedProject struct {
+	*Project
+	Info           *ProjectInfo
+	Devices        []*ResolvedDevice
+	GroupAddresses []*ResolvedGroupAddress
+}
+
+// ResolvedDevice is a device instance linked with its product, hardware
+// and application program. Area and Line are nil for unassigned devices.
+type ResolvedDevice struct {
+	*DeviceInstance
+	Installation     *Installation
+	Area             *Area
+	Line             *Line
+	Segment          *Segment // nil for projects before ETS6
+	Role             DeviceRole
+	Product          *Product
+	Hardware         *Hardware
+	Hardware2Program *Hardware2Program
+	Program          *ApplicationProgram
+	ComObjects       []*ResolvedComObject
+
+	texts *TextResolver
+}
+
+// ResolvedComObject is a communication object of a device linked with
+// its communication object reference, communication object and group addresses.
+// Ref and Object are nil if the application program of the device is unknown.
+type ResolvedComObject struct {
+	Instance            *ComObjectInstanceRef
+	Ref                 *ComObjectRef
+	Object              *ComObject
+	Device              *ResolvedDevice
+	GroupAddresses      []*ResolvedGroupAddress
+	SendingGroupAddress *ResolvedGroupAddress // nil if the object doesn't send to a group address
+}
+
+// ResolvedGroupAddress is a group address linked with the communication objects
+// connected to it.
+type ResolvedGroupAddress struct {
+	*GroupAddress
+	Installation *Installation
+	Range        *GroupRange
+	ComObjects   []*ResolvedComObject
+}
+
+// Devices returns the devices which are connected to the group address.
+func (ga *ResolvedGroupAddress) Devices() []*ResolvedDevice {
+	var devices []*ResolvedDevice
+	seen := map[*ResolvedDevice]bool{}
+	for _, obj := range ga.ComObjects {
+		if !seen[obj.Device] {
+			seen[obj.Device] = true
+			devices = append(devices, obj.Device)
+		}
+	}
+
+	return devices
+}
+
+type programKey struct {
+	ManufacturerID ManufacturerID
+	ID             ApplicationProgramID
+}
+
+type productKey struct {
+	ManufacturerID ManufacturerID
+	HardwareID     HardwareID
+	ID             ProductID
+}
+
+type hardware2ProgramKey struct {
+	ManufacturerID ManufacturerID
+	HardwareID     HardwareID
+	ID             Hardware2ProgramID
+}
+
+// TextResolver returns the resolver for the texts of the device's application program.
+// The result is nil if the application program is unknown.
+func (d *ResolvedDevice) TextResolver() *TextResolver {
+	if d.Program == nil {
+		return nil
+	}
+
+	if d.texts == nil {
+		d.texts = NewTextResolver(d.Program, d.DeviceInstance)
+	}
+
+	return d.texts
+}
+
+// ActiveObjectRefs returns the communication object references of the device's application
+// program which are active for the configured parameter values. The result is nil if the
+// application program is unknown.
+func (d *ResolvedDevice) ActiveObjectRefs() []ComObjectRef {
+	if d.Program == nil {
+		return nil
+	}
+
+	return d.Program.ActiveObjectRefs(d.Program.ParameterValues(d.DeviceInstance))
+}
+
+// resolver contains the manufacturer and hardware data indexed by their ids.
+type resolver struct {
+	programs          map[programKey]*ApplicationProgram
+	products          map[productKey]*Product
+	hardwares         map[productKey]*Hardware
+	hardware2Programs map[hardware2ProgramKey]*Hardware2Program
+	objects           map[*ApplicationProgram]*comObjectIndex
+}
+
+func newResolver(manufacturers []*ManufacturerData, hardware []*HardwareData) *resolver {
+	r := &resolver{
+		programs:          map[programKey]*ApplicationProgram{},
+		products:          map[productKey]*Product{},
+		hardwares:         map[productKey]*Hardware{},
+		hardware2Programs: map[hardware2ProgramKey]*Hardware2Program{},
+		objects:           map[*ApplicationProgram]*comObjectIndex{},
+	}
+
+	for _, md := range manufacturers {
+		for i := range md.Programs {
+			prog := &md.Programs[i]
+			r.programs[programKey{prog.ManufacturerID, prog.ID}] = prog
+			r.objects[prog] = newComObjectIndex(prog)
+		}
+	}
+
+	for _, hd := range hardware {
+		for i := range hd.Hardwares {
+			hw := &hd.Hardwares[i]
+			for j := range hw.Products {
+				prod := &hw.Products[j]
+				key := productKey{prod.ManufacturerID, prod.HardwareID, prod.ID}
+				r.products[key] = prod
+				r.hardwares[key] = hw
+			}
+
+			for j := range hw.Hardware2Programs {
+				hp := &hw.Hardware2Programs[j]
+				r.hardware2Programs[hardware2ProgramKey{hp.ManufacturerID, hp.HardwareID, hp.ID}] = hp
+			}
+		}
+	}
+
+	return r
+}
+
+// Resolve links the devices, application programs, communication objects and
+// group addresses of the project. References which cannot be found in the manufacturer
+// and hardware data remain nil.
+func Resolve(p *Project, manufacturers []*ManufacturerData, hardware []*HardwareData) *ResolvedProject {
+	r := newResolver(manufacturers, hardware)
+	rp := &ResolvedProject{Project: p}
+
+	for i := range p.Installations {
+		inst := &p.Installations[i]
+
+		addrs := map[GroupAddressID]*ResolvedGroupAddress{}
+		walkGroupRanges(inst.GroupAddresses, func(gr *GroupRange) {
+			for j := range gr.Addresses {
+				ga := &ResolvedGroupAddress{
+					GroupAddress: &gr.Addresses[j],
+					Installation: inst,
+					Range:        gr,
+				}
+				addrs[ga.ID] = ga
+				rp.GroupAddresses = append(rp.GroupAddresses, ga)
+			}
+		})
+
+		for j := range inst.Topology {
+			area := &inst.Topology[j]
+			for k := range area.Lines {
+				line := &area.Lines[k]
+				for l := range line.Devices {
+					dev := r.resolveDevice(&line.Devices[l], addrs)
+					dev.Installation = inst
+					dev.Area = area
+					dev.Line = line
+					dev.Segment = line.Segment(dev.ID)
+					rp.Devices = append(rp.Devices, dev)
+				}
+			}
+		}
+
+		for j := range inst.UnassignedDevices {
+			dev := r.resolveDevice(&inst.UnassignedDevices[j], addrs)
+			dev.Installation = inst
+			rp.Devices = append(rp.Devices, dev)
+		}
+	}
+
+	return rp
+}
+
+func (r *resolver) resolveDevice(di *DeviceInstance, addrs map[GroupAddressID]*ResolvedGroupAddress) *ResolvedDevice {
+	dev := &ResolvedDevice{DeviceInstance: di}
+
+	prodKey := productKey{di.ManufacturerID, di.HardwareID, di.ProductID}
+	dev.Product = r.products[prodKey]
+	dev.Hardware = r.hardwares[prodKey]
+	dev.Hardware2Program = r.hardware2Programs[hardware2ProgramKey{di.ManufacturerID, di.HardwareID, di.Hardware2ProgramID}]
+	dev.Role = deviceRole(dev.Hardware, di.IndividualAddress)
+
+	// A hardware can reference multiple application programs (e.g. for plugins).
+	// The communication objects are defined by the first of them which contains any of the objects.
+	var programs []*ApplicationProgram
+	if dev.Hardware2Program != nil {
+		for _, id := range dev.Hardware2Program.ApplicationProgramIDs {
+			if prog, ok := r.programs[programKey{di.ManufacturerID, id}]; ok {
+				programs = append(programs, prog)
+			}
+		}
+	}
+
+	if len(programs) > 0 {
+		dev.Program = programs[0]
+	}
+
+	var objects comObjectSources
+	for _, prog := range programs {
+		if src := r.comObjectSources(prog, di); src.resolvesAny(di.ComObjects) {
+			dev.Program, objects = prog, src
+			break
+		}
+	}
+
+	for i := range di.ComObjects {
+		obj := &ResolvedComObject{
+			Instance: &di.ComObjects[i],
+			Device:   dev,
+		}
+		obj.Ref, obj.Object = objects.lookup(obj.Instance)
+
+		for _, link := range obj.Instance.Links {
+			if ga, ok := addrs[GroupAddressID(link)]; ok {
+				obj.GroupAddresses = append(obj.GroupAddresses, ga)
+				ga.ComObjects = append(ga.ComObjects, obj)
+			}
+		}
+
+		if id, ok := obj.Instance.SendingGroupAddress(); ok {
+			obj.SendingGroupAddress = addrs[id]
+		}
+
+		dev.ComObjects = append(dev.ComObjects, obj)
+	}
+
+	return dev
+}
+
+// comObjectSources are the indexes of the communication objects of a device.
+type comObjectSources []*comObjectIndex
+
+// comObjectSources returns the index of the program and, if the device instantiates modules,
+// an index of the objects of the modules.
+func (r *resolver) comObjectSources(prog *ApplicationProgram, di *DeviceInstance) comObjectSources {
+	src := comObjectSources{r.objects[prog]}
+	// Modules can also be instantiated by the device instead of the application program.
+	if len(di.ModuleInstances) > 0 {
+		objs, refs := prog.instantiateModules(di.ModuleInstances)
+		src = append(src, newComObjectIndex(&ApplicationProgram{Objects: objs, ObjectRefs: refs}))
+	}
+
+	return src
+}
+
+// lookup returns the communication object reference and communication object of the instance.
+func (src comObjectSources) lookup(ci *ComObjectInstanceRef) (*ComObjectRef, *ComObject) {
+	for _, idx := range src {
+		if ref, obj := idx.lookup(ci.ComObjectID, ci.ComObjectRefID, ci.ModuleInstanceID); ref != nil {
+			return ref, obj
+		}
+	}
+
+	return nil, nil
+}
+
+// resolvesAny returns true if any of the instances is defined by the sources.
+func (src comObjectSources) resolvesAny(instances []ComObjectInstanceRef) bool {
+	for i := range instances {
+		if ref, _ := src.lookup(&instances[i]); ref != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+type comObjectRefKey struct {
+	ID               ComObjectRefID
+	ModuleInstanceID ModuleInstanceID
+}
+
+type comObjectKey struct {
+	ID               ComObjectID
+	ModuleInstanceID ModuleInstanceID
+}
+
+// comObjectIndex contains the communication object references and communication objects
+// of an application program indexed by their ids and module instances.
+type comObjectIndex struct {
+	refs    map[comObjectRefKey][]*ComObjectRef
+	objects map[comObjectKey]*ComObject
+}
+
+func newComObjectIndex(ap *ApplicationProgram) *comObjectIndex {
+	idx := &comObjectIndex{
+		refs:    map[comObjectRefKey][]*ComObjectRef{},
+		objects: map[comObjectKey]*ComObject{},
+	}
+
+	for i := range ap.ObjectRefs {
+		ref := &ap.ObjectRefs[i]
+		key := comObjectRefKey{ref.ID, ref.ModuleInstanceID}
+		idx.refs[key] = append(idx.refs[key], ref)
+	}
+
+	for i := range ap.Objects {
+		obj := &ap.Objects[i]
+		if key := (comObjectKey{obj.ID, obj.ModuleInstanceID}); idx.objects[key] == nil {
+			idx.objects[key] = obj
+		}
+	}
+
+	return idx
+}
+
+// lookup returns the communication object reference and communication object
+// with the given ids. The module instance is empty for objects which are not part of a module.
+func (idx *comObjectIndex) lookup(objID ComObjectID, refID ComObjectRefID, mi ModuleInstanceID) (*ComObjectRef, *ComObject) {
+	if idx == nil {
+		return nil, nil
+	}
+
+	for _, ref := range idx.refs[comObjectRefKey{refID, mi}] {
+		if len(objID) > 0 && ref.ComObjectID != objID {
+			continue
+		}
+
+		return ref, idx.objects[comObjectKey{ref.ComObjectID, mi}]
+	}
+
+	return nil, nil
+}
+
+// walkGroupRanges calls fn for every group range and its sub ranges.
+func walkGroupRanges(ranges []GroupRange, fn func(*GroupRange)) {
+	for i := range ranges {
+		fn(&ranges[i])
+		walkGroupRanges(ranges[i].SubRanges, fn)
+	}
+}
+
+// ResolveProjects decodes the projects, manufacturer and hardware files
+// in the archive and returns the resolved projects.
+func (ex *ExportArchive) ResolveProjects() ([]*ResolvedProject, error) {
+	var manufacturers []*ManufacturerData
+	for _, mf := range ex.ManufacturerFiles {
+		md, err := mf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		manufacturers = append(manufacturers, md)
+	}
+
+	var hardware []*HardwareData
+	for _, hf := range ex.HardwareFiles {
+		hd, err := hf.Decode()
+		if err != nil {
+			return nil, err
+		}
+		hardware = append(hardware, hd)
+	}
+
+	var projects []*ResolvedProject
+	for _, pf := range ex.ProjectFiles {
+		info, err := pf.Decode()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, inst := range pf.InstallationFiles {
+			p, err := inst.Decode()
+			if err != nil {
+				return nil, err
+			}
+
+			rp := Resolve(p, manufacturers, hardware)
+			rp.Info = info
+			projects = append(projects, rp)
+		}
+	}
+
+	return projects, nil
+}
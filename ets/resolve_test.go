@@ -0,0 +1,92 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestResolveProjects(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	projects, err := archive.ResolveProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if is, want := len(projects), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	proj := projects[0]
+	if is, want := proj.Info.Name, "Testproject"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(proj.Devices), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	dev := proj.Devices[0]
+	if dev.Program == nil || dev.Program.ID != "A-3120-32-269B" {
+		t.Fatalf("Unexpected application program %v", dev.Program)
+	}
+
+	if is, want := dev.Line.ID, LineID("L-3"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(dev.ComObjects), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	obj := dev.ComObjects[0]
+	if obj.Ref == nil || obj.Ref.ID != "R-1" {
+		t.Fatalf("Unexpected communication object reference %v", obj.Ref)
+	}
+
+	if obj.Object == nil || obj.Object.Name != "Obj_SwitchOnOff" {
+		t.Fatalf("Unexpected communication object %v", obj.Object)
+	}
+
+	eff := obj.Effective()
+	if is, want := eff.ObjectSize, "1 Bit"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := eff.FunctionText, "Ausgang"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if !eff.TransmitFlag || eff.ReadFlag {
+		t.Fatalf("Unexpected flags %+v", eff)
+	}
+
+	if is, want := len(proj.GroupAddresses), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	ga := proj.GroupAddresses[0]
+	if is, want := ga.Range.ID, GroupRangeID("P-0497-0_GR-2"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(ga.ComObjects), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	devices := ga.Devices()
+	if is, want := len(devices), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := devices[1].ID, DeviceInstanceID("DI-2"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if devices[1].Product == nil || devices[1].Hardware == nil {
+		t.Fatal("Product or hardware not resolved")
+	}
+}
@@ -0,0 +1,62 @@
+package ets
+
+// DeviceRole is the role of a device in the topology.
+type DeviceRole int
+
+const (
+	// DeviceRoleDevice is an ordinary device.
+	DeviceRoleDevice DeviceRole = iota
+	// DeviceRoleLineCoupler is a coupler with the address x.y.0, which couples the line x.y to its area.
+	DeviceRoleLineCoupler
+	// DeviceRoleAreaCoupler is a coupler with the address x.0.0, which couples the area x to the backbone.
+	DeviceRoleAreaCoupler
+	// DeviceRoleCoupler is a coupler whose address doesn't couple a line or area, e.g. a repeater
+	// or a coupler without an individual address.
+	DeviceRoleCoupler
+)
+
+func (r DeviceRole) String() string {
+	switch r {
+	case DeviceRoleDevice:
+		return "Device"
+	case DeviceRoleLineCoupler:
+		return "LineCoupler"
+	case DeviceRoleAreaCoupler:
+		return "AreaCoupler"
+	case DeviceRoleCoupler:
+		return "Coupler"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsCoupler returns true if the role is a coupler role.
+func (r DeviceRole) IsCoupler() bool {
+	return r != DeviceRoleDevice
+}
+
+// IsRouter returns true if the device is an IP router, which is a coupler with an IP interface.
+func (d *ResolvedDevice) IsRouter() bool {
+	return d.Role.IsCoupler() && d.Hardware != nil && d.Hardware.IsIPEnabled
+}
+
+// deviceRole returns the role of a device with the hardware and individual address.
+// If the hardware is unknown, a device with the address x.y.0 is considered to be
+// a coupler, because ETS reserves the device address 0 for couplers.
+func deviceRole(hw *Hardware, addr *IndividualAddress) DeviceRole {
+	coupler := hw != nil && hw.IsCoupler
+	if hw == nil && addr != nil && addr.Device() == 0 {
+		coupler = true
+	}
+
+	switch {
+	case !coupler:
+		return DeviceRoleDevice
+	case addr == nil || addr.Device() != 0:
+		return DeviceRoleCoupler
+	case addr.Line() == 0:
+		return DeviceRoleAreaCoupler
+	default:
+		return DeviceRoleLineCoupler
+	}
+}
@@ -0,0 +1,30 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestDeviceRole(t *testing.T) {
+	coupler := &Hardware{IsCoupler: true}
+	device := &Hardware{}
+
+	tests := []struct {
+		hw   *Hardware
+		addr *IndividualAddress
+		role DeviceRole
+	}{
+		{device, newIndividualAddress(1, 1, 1), DeviceRoleDevice},
+		{coupler, newIndividualAddress(1, 1, 0), DeviceRoleLineCoupler},
+		{coupler, newIndividualAddress(1, 0, 0), DeviceRoleAreaCoupler},
+		{coupler, newIndividualAddress(1, 1, 5), DeviceRoleCoupler},
+		{coupler, nil, DeviceRoleCoupler},
+		{nil, newIndividualAddress(1, 2, 0), DeviceRoleLineCoupler},
+		{nil, newIndividualAddress(1, 2, 3), DeviceRoleDevice},
+	}
+
+	for _, test := range tests {
+		if is, want := deviceRole(test.hw, test.addr), test.role; is != want {
+			t.Fatalf("%v: %v != %v", test.addr, is, want)
+		}
+	}
+}
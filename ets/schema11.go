@@ -3,6 +3,7 @@ package ets
 import (
 	"encoding/xml"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -46,21 +47,42 @@ type deviceInstance11 DeviceInstance
 
 func (di *deviceInstance11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID         string `xml:"Id,attr"`
-		ProductID  string `xml:"ProductRefId,attr"`
-		ProgramID  string `xml:"Hardware2ProgramRefId,attr"`
-		Name       string `xml:",attr"`
-		Address    uint16 `xml:",attr"`
+		ID         string  `xml:"Id,attr"`
+		ProductID  string  `xml:"ProductRefId,attr"`
+		ProgramID  string  `xml:"Hardware2ProgramRefId,attr"`
+		Name       string  `xml:",attr"`
+		Address    *uint16 `xml:",attr"`
 		ComObjects []struct {
-			RefID         string `xml:"RefId,attr"`
-			DatapointType string `xml:",attr"`
-			Connectors    struct {
+			RefID             string `xml:"RefId,attr"`
+			Text              string `xml:",attr"`
+			FunctionText      string `xml:",attr"`
+			DatapointType     string `xml:",attr"`
+			Priority          string `xml:",attr"`
+			ReadFlag          string `xml:",attr"`
+			WriteFlag         string `xml:",attr"`
+			CommunicationFlag string `xml:",attr"`
+			TransmitFlag      string `xml:",attr"`
+			UpdateFlag        string `xml:",attr"`
+			ReadOnInitFlag    string `xml:",attr"`
+			Connectors        struct {
 				Elements []struct {
 					XMLName xml.Name
 					RefID   string `xml:"GroupAddressRefId,attr"`
 				} `xml:",any"`
 			}
 		} `xml:"ComObjectInstanceRefs>ComObjectInstanceRef"`
+		Parameters []struct {
+			RefID string `xml:"RefId,attr"`
+			Value string `xml:",attr"`
+		} `xml:"ParameterInstanceRefs>ParameterInstanceRef"`
+		ModuleInstances []struct {
+			ID        string `xml:"Id,attr"`
+			RefID     string `xml:"RefId,attr"`
+			Arguments []struct {
+				RefID string `xml:"RefId,attr"`
+				Value string `xml:",attr"`
+			} `xml:"Arguments>Argument"`
+		} `xml:"ModuleInstances>ModuleInstance"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -86,7 +108,10 @@ func (di *deviceInstance11) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	}
 
 	di.Name = doc.Name
-	di.Address = doc.Address
+	if doc.Address != nil {
+		di.Address = *doc.Address
+		di.hasAddress = true
+	}
 	di.ComObjects = make([]ComObjectInstanceRef, len(doc.ComObjects))
 
 	for n, docComObj := range doc.ComObjects {
@@ -106,24 +131,64 @@ func (di *deviceInstance11) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 		}
 
 		var links = []string{}
+		var connectors = []Connector{}
 		for _, docConnElem := range docComObj.Connectors.Elements {
 			ids := strings.Split(docConnElem.RefID, "_")
 			if len(ids) == 2 && len(ids[1]) > 0 {
 				links = append(links, ids[1])
+				connectors = append(connectors, Connector{
+					Receive: docConnElem.XMLName.Local == "Receive",
+					RefID:   GroupAddressID(ids[1]),
+				})
 			}
 		}
 
 		comObj := ComObjectInstanceRef{
-			ComObjectID:    comObjID,
-			ComObjectRefID: comObjRefID,
-			DatapointType:  docComObj.DatapointType,
-			Links:          links,
+			ComObjectID:       comObjID,
+			ComObjectRefID:    comObjRefID,
+			ModuleInstanceID:  moduleInstanceID(docComObj.RefID),
+			Text:              docComObj.Text,
+			FunctionText:      docComObj.FunctionText,
+			DatapointType:     docComObj.DatapointType,
+			Priority:          docComObj.Priority,
+			Links:             links,
+			Connectors:        connectors,
+			ReadFlag:          parseFlag(docComObj.ReadFlag),
+			WriteFlag:         parseFlag(docComObj.WriteFlag),
+			CommunicationFlag: parseFlag(docComObj.CommunicationFlag),
+			TransmitFlag:      parseFlag(docComObj.TransmitFlag),
+			UpdateFlag:        parseFlag(docComObj.UpdateFlag),
+			ReadOnInitFlag:    parseFlag(docComObj.ReadOnInitFlag),
 		}
 
 		di.ComObjects[n] = comObj
 
 	}
 
+	di.Parameters = make([]ParameterInstanceRef, len(doc.Parameters))
+	for n, docParam := range doc.Parameters {
+		di.Parameters[n] = ParameterInstanceRef{
+			RefID: ParameterRefID(programRelativeID(docParam.RefID)),
+			Value: docParam.Value,
+		}
+	}
+
+	di.ModuleInstances = make([]ModuleInstance, len(doc.ModuleInstances))
+	for n, docModule := range doc.ModuleInstances {
+		mi := ModuleInstance{
+			ID:          ModuleInstanceID(programRelativeID(docModule.ID)),
+			ModuleDefID: ModuleID(programRelativeID(docModule.RefID)),
+			Arguments:   make([]ModuleArgumentValue, len(docModule.Arguments)),
+		}
+		for i, arg := range docModule.Arguments {
+			mi.Arguments[i] = ModuleArgumentValue{
+				ArgumentID: programRelativeID(arg.RefID),
+				Value:      arg.Value,
+			}
+		}
+		di.ModuleInstances[n] = mi
+	}
+
 	return nil
 }
 
@@ -175,6 +240,7 @@ func (a *area11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	for n, docLine := range doc.Line {
 		a.Lines[n] = Line(docLine)
 	}
+	(*Area)(a).setIndividualAddresses()
 
 	return nil
 }
@@ -245,8 +311,8 @@ func (gar *groupRange11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 
 	gar.ID = GroupRangeID(doc.ID)
 	gar.Name = doc.Name
-	gar.RangeStart = doc.RangeStart
-	gar.RangeEnd = doc.RangeEnd
+	gar.RangeStart = GroupAddressValue(doc.RangeStart)
+	gar.RangeEnd = GroupAddressValue(doc.RangeEnd)
 	gar.Addresses = make([]GroupAddress, len(doc.GroupAddress))
 	gar.SubRanges = make([]GroupRange, len(doc.GroupRange))
 
@@ -261,7 +327,7 @@ func (gar *groupRange11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 			ID:            GroupAddressID(ids[1]),
 			Name:          ga.Name,
 			Description:   ga.Description,
-			Address:       ga.Address,
+			Address:       GroupAddressValue(ga.Address),
 			DatapointType: ga.DatapointType,
 		}
 	}
@@ -277,10 +343,11 @@ type installation11 Installation
 
 func (i *installation11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		Name        string         `xml:",attr"`
-		Areas       []area11       `xml:"Topology>Area"`
-		GroupRanges []groupRange11 `xml:"GroupAddresses>GroupRanges>GroupRange"`
-		Locations   []space11      `xml:"Locations>Space"`
+		Name              string             `xml:",attr"`
+		Areas             []area11           `xml:"Topology>Area"`
+		UnassignedDevices []deviceInstance11 `xml:"Topology>UnassignedDevices>DeviceInstance"`
+		GroupRanges       []groupRange11     `xml:"GroupAddresses>GroupRanges>GroupRange"`
+		Locations         []space11          `xml:"Locations>Space"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -296,6 +363,11 @@ func (i *installation11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		i.Topology[n] = Area(docArea)
 	}
 
+	i.UnassignedDevices = make([]DeviceInstance, len(doc.UnassignedDevices))
+	for n, docDevice := range doc.UnassignedDevices {
+		i.UnassignedDevices[n] = DeviceInstance(docDevice)
+	}
+
 	for n, docGrpRange := range doc.GroupRanges {
 		i.GroupAddresses[n] = GroupRange(docGrpRange)
 	}
@@ -371,19 +443,23 @@ type comObject11 ComObject
 
 func (co *comObject11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID                string `xml:"Id,attr"`
-		Name              string `xml:",attr"`
-		Text              string `xml:",attr"`
-		FunctionText      string `xml:",attr"`
-		ObjectSize        string `xml:",attr"`
-		DatapointType     string `xml:",attr"`
-		Priority          string `xml:",attr"`
-		ReadFlag          string `xml:",attr"`
-		WriteFlag         string `xml:",attr"`
-		CommunicationFlag string `xml:",attr"`
-		TransmitFlag      string `xml:",attr"`
-		UpdateFlag        string `xml:",attr"`
-		ReadOnInitFlag    string `xml:",attr"`
+		ID                 string `xml:"Id,attr"`
+		Name               string `xml:",attr"`
+		Number             uint   `xml:",attr"`
+		BaseNumber         string `xml:",attr"`
+		TextParameterRefID string `xml:"TextParameterRefId,attr"`
+		Text               string `xml:",attr"`
+		Description        string `xml:",attr"`
+		FunctionText       string `xml:",attr"`
+		ObjectSize         string `xml:",attr"`
+		DatapointType      string `xml:",attr"`
+		Priority           string `xml:",attr"`
+		ReadFlag           string `xml:",attr"`
+		WriteFlag          string `xml:",attr"`
+		CommunicationFlag  string `xml:",attr"`
+		TransmitFlag       string `xml:",attr"`
+		UpdateFlag         string `xml:",attr"`
+		ReadOnInitFlag     string `xml:",attr"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -400,17 +476,25 @@ func (co *comObject11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) erro
 	co.ID = ids.ComObject
 	co.ModuleID = ids.Module
 	co.Name = doc.Name
+	co.Number = doc.Number
+	if len(doc.BaseNumber) > 0 {
+		co.BaseNumber = programRelativeID(doc.BaseNumber)
+	}
+	if len(doc.TextParameterRefID) > 0 {
+		co.TextParameterRefID = ParameterRefID(programRelativeID(doc.TextParameterRefID))
+	}
 	co.Text = doc.Text
+	co.Description = doc.Description
 	co.FunctionText = doc.FunctionText
 	co.ObjectSize = doc.ObjectSize
 	co.DatapointType = doc.DatapointType
 	co.Priority = doc.Priority
-	co.ReadFlag = doc.ReadFlag == "Enabled"
-	co.WriteFlag = doc.WriteFlag == "Enabled"
-	co.CommunicationFlag = doc.CommunicationFlag == "Enabled"
-	co.TransmitFlag = doc.TransmitFlag == "Enabled"
-	co.UpdateFlag = doc.UpdateFlag == "Enabled"
-	co.ReadOnInitFlag = doc.ReadOnInitFlag == "Enabled"
+	co.ReadFlag = parseFlag(doc.ReadFlag)
+	co.WriteFlag = parseFlag(doc.WriteFlag)
+	co.CommunicationFlag = parseFlag(doc.CommunicationFlag)
+	co.TransmitFlag = parseFlag(doc.TransmitFlag)
+	co.UpdateFlag = parseFlag(doc.UpdateFlag)
+	co.ReadOnInitFlag = parseFlag(doc.ReadOnInitFlag)
 
 	return nil
 }
@@ -420,21 +504,22 @@ type comObjectRef11 ComObjectRef
 // Id="M-0080_A-1012-10-5227-O00C5_O-0_R-1" RefId="M-0080_A-1012-10-5227-O00C5_O-0"
 func (cor *comObjectRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID                string `xml:"Id,attr"`
-		RefID             string `xml:"RefId,attr"`
-		Name              string `xml:",attr"`
-		Text              string `xml:",attr"`
-		Description       string `xml:",attr"`
-		FunctionText      string `xml:",attr"`
-		ObjectSize        string `xml:",attr"`
-		DatapointType     string `xml:",attr"`
-		Priority          string `xml:",attr"`
-		ReadFlag          string `xml:",attr"`
-		WriteFlag         string `xml:",attr"`
-		CommunicationFlag string `xml:",attr"`
-		TransmitFlag      string `xml:",attr"`
-		UpdateFlag        string `xml:",attr"`
-		ReadOnInitFlag    string `xml:",attr"`
+		ID                 string `xml:"Id,attr"`
+		RefID              string `xml:"RefId,attr"`
+		Name               string `xml:",attr"`
+		Text               string `xml:",attr"`
+		TextParameterRefID string `xml:"TextParameterRefId,attr"`
+		Description        string `xml:",attr"`
+		FunctionText       string `xml:",attr"`
+		ObjectSize         string `xml:",attr"`
+		DatapointType      string `xml:",attr"`
+		Priority           string `xml:",attr"`
+		ReadFlag           string `xml:",attr"`
+		WriteFlag          string `xml:",attr"`
+		CommunicationFlag  string `xml:",attr"`
+		TransmitFlag       string `xml:",attr"`
+		UpdateFlag         string `xml:",attr"`
+		ReadOnInitFlag     string `xml:",attr"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -451,22 +536,212 @@ func (cor *comObjectRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	cor.ApplicationProgramID = ids.AppProgram
 	cor.ComObjectID = ids.ComObject
 	cor.ID = ids.ComObjectRef
+	cor.ModuleID = ids.Module
+	if len(doc.TextParameterRefID) > 0 {
+		cor.TextParameterRefID = ParameterRefID(programRelativeID(doc.TextParameterRefID))
+	}
 	cor.Name = doc.Name
 	cor.Text = doc.Text
+	cor.Description = doc.Description
 	cor.FunctionText = doc.FunctionText
 	cor.ObjectSize = doc.ObjectSize
 	cor.DatapointType = doc.DatapointType
 	cor.Priority = doc.Priority
-	cor.ReadFlag = doc.ReadFlag == "Enabled"
-	cor.WriteFlag = doc.WriteFlag == "Enabled"
-	cor.CommunicationFlag = doc.CommunicationFlag == "Enabled"
-	cor.TransmitFlag = doc.TransmitFlag == "Enabled"
-	cor.UpdateFlag = doc.UpdateFlag == "Enabled"
-	cor.ReadOnInitFlag = doc.ReadOnInitFlag == "Enabled"
+	cor.ReadFlag = parseFlag(doc.ReadFlag)
+	cor.WriteFlag = parseFlag(doc.WriteFlag)
+	cor.CommunicationFlag = parseFlag(doc.CommunicationFlag)
+	cor.TransmitFlag = parseFlag(doc.TransmitFlag)
+	cor.UpdateFlag = parseFlag(doc.UpdateFlag)
+	cor.ReadOnInitFlag = parseFlag(doc.ReadOnInitFlag)
+
+	return nil
+}
+
+type parameterType11 ParameterType
+
+func (pt *parameterType11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type numberType struct {
+		SizeInBit int    `xml:",attr"`
+		Type      string `xml:",attr"`
+		Unit      string `xml:",attr"`
+		Min       string `xml:"minInclusive,attr"`
+		Max       string `xml:"maxInclusive,attr"`
+	}
+
+	var doc struct {
+		ID              string `xml:"Id,attr"`
+		Name            string `xml:",attr"`
+		TypeNumber      *numberType
+		TypeFloat       *numberType
+		TypeTime        *numberType
+		TypeRestriction *struct {
+			SizeInBit    int `xml:",attr"`
+			Enumerations []struct {
+				ID    string `xml:"Id,attr"`
+				Text  string `xml:",attr"`
+				Value string `xml:",attr"`
+			} `xml:"Enumeration"`
+		}
+		TypeText *struct {
+			SizeInBit int `xml:",attr"`
+		}
+		TypeNone *struct{}
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	pt.ID = ParameterTypeID(programRelativeID(doc.ID))
+	pt.Name = doc.Name
+
+	var number *numberType
+	switch {
+	case doc.TypeNumber != nil:
+		pt.Kind = ParameterTypeNumber
+		number = doc.TypeNumber
+	case doc.TypeFloat != nil:
+		pt.Kind = ParameterTypeFloat
+		number = doc.TypeFloat
+	case doc.TypeTime != nil:
+		pt.Kind = ParameterTypeTime
+		number = doc.TypeTime
+	case doc.TypeRestriction != nil:
+		pt.Kind = ParameterTypeEnum
+		pt.SizeInBit = doc.TypeRestriction.SizeInBit
+		pt.Enumerations = make([]ParameterEnumeration, len(doc.TypeRestriction.Enumerations))
+		for n, docEnum := range doc.TypeRestriction.Enumerations {
+			pt.Enumerations[n] = ParameterEnumeration{
+				ID:    programRelativeID(docEnum.ID),
+				Text:  docEnum.Text,
+				Value: docEnum.Value,
+			}
+		}
+	case doc.TypeText != nil:
+		pt.Kind = ParameterTypeText
+		pt.SizeInBit = doc.TypeText.SizeInBit
+	case doc.TypeNone != nil:
+		pt.Kind = ParameterTypeNone
+	default:
+		pt.Kind = ParameterTypeOther
+	}
+
+	if number != nil {
+		pt.SizeInBit = number.SizeInBit
+		pt.NumberType = number.Type
+		pt.Unit = number.Unit
+		pt.Min = parseFloatAttr(number.Min)
+		pt.Max = parseFloatAttr(number.Max)
+	}
+
+	return nil
+}
+
+type parameter11 struct {
+	Parameter
+	TypeID ParameterTypeID
+}
+
+func (p *parameter11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID            string `xml:"Id,attr"`
+		Name          string `xml:",attr"`
+		ParameterType string `xml:",attr"`
+		Text          string `xml:",attr"`
+		SuffixText    string `xml:",attr"`
+		Access        string `xml:",attr"`
+		Value         string `xml:",attr"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	p.ID = ParameterID(programRelativeID(doc.ID))
+	p.TypeID = ParameterTypeID(programRelativeID(doc.ParameterType))
+	p.Name = doc.Name
+	p.Text = doc.Text
+	p.SuffixText = doc.SuffixText
+	p.Access = doc.Access
+	p.Value = doc.Value
+
+	return nil
+}
+
+type parameterRef11 ParameterRef
+
+func (pr *parameterRef11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID     string `xml:"Id,attr"`
+		RefID  string `xml:"RefId,attr"`
+		Text   string `xml:",attr"`
+		Access string `xml:",attr"`
+		Value  string `xml:",attr"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	pr.ID = ParameterRefID(programRelativeID(doc.ID))
+	pr.ParameterID = ParameterID(programRelativeID(doc.RefID))
+	pr.Text = doc.Text
+	pr.Access = doc.Access
+	pr.Value = doc.Value
 
 	return nil
 }
 
+type dynamicNode11 DynamicNode
+
+func (n *dynamicNode11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n.Kind = start.Name.Local
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "Id":
+			n.ID = programRelativeID(attr.Value)
+		case "RefId":
+			n.RefID = programRelativeID(attr.Value)
+		case "ParamRefId":
+			n.ParamRefID = ParameterRefID(programRelativeID(attr.Value))
+		case "TextParameterRefId":
+			n.TextParamRefID = ParameterRefID(programRelativeID(attr.Value))
+		case "Name":
+			n.Name = attr.Value
+		case "Text":
+			n.Text = attr.Value
+		case "Number":
+			n.Number = attr.Value
+		case "Access":
+			n.Access = attr.Value
+		case "Value":
+			n.Value = attr.Value
+		case "test":
+			n.Test = attr.Value
+		case "default":
+			n.Default = attr.Value == "true"
+		}
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var child dynamicNode11
+			if err := d.DecodeElement(&child, &t); err != nil {
+				return err
+			}
+			n.Children = append(n.Children, DynamicNode(child))
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
 type applicationProgram11 ApplicationProgram
 
 func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -476,14 +751,32 @@ func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElem
 		Name    string `xml:",attr"`
 		Version uint   `xml:"ApplicationVersion,attr"`
 		Static  struct {
-			Objects    []comObject11    `xml:"ComObjectTable>ComObject"`
-			ObjectRefs []comObjectRef11 `xml:"ComObjectRefs>ComObjectRef"`
+			Objects         []comObject11     `xml:"ComObjectTable>ComObject"`
+			ObjectRefs      []comObjectRef11  `xml:"ComObjectRefs>ComObjectRef"`
+			ParameterTypes  []parameterType11 `xml:"ParameterTypes>ParameterType"`
+			Parameters      []parameter11     `xml:"Parameters>Parameter"`
+			UnionParameters []parameter11     `xml:"Parameters>Union>Parameter"`
+			ParameterRefs   []parameterRef11  `xml:"ParameterRefs>ParameterRef"`
 		}
 		Modules []struct {
-			ID         string           `xml:"Id,attr"` // Id="M-0080_A-1012-10-5227-O00C5_MD-1"
-			Objects    []comObject11    `xml:"Static>ComObjects>ComObject"`
-			ObjectRefs []comObjectRef11 `xml:"Static>ComObjectRefs>ComObjectRef"`
+			ID        string `xml:"Id,attr"` // Id="M-0080_A-1012-10-5227-O00C5_MD-1"
+			Name      string `xml:",attr"`
+			Arguments []struct {
+				ID   string `xml:"Id,attr"`
+				Name string `xml:",attr"`
+			} `xml:"Arguments>Argument"`
+			Dynamic struct {
+				Nodes []dynamicNode11 `xml:",any"`
+			}
+			Objects         []comObject11    `xml:"Static>ComObjects>ComObject"`
+			ObjectRefs      []comObjectRef11 `xml:"Static>ComObjectRefs>ComObjectRef"`
+			Parameters      []parameter11    `xml:"Static>Parameters>Parameter"`
+			UnionParameters []parameter11    `xml:"Static>Parameters>Union>Parameter"`
+			ParameterRefs   []parameterRef11 `xml:"Static>ParameterRefs>ParameterRef"`
 		} `xml:"ModuleDefs>ModuleDef"`
+		Dynamic *struct {
+			Nodes []dynamicNode11 `xml:",any"`
+		}
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -500,24 +793,90 @@ func (ap *applicationProgram11) UnmarshalXML(d *xml.Decoder, start xml.StartElem
 	ap.Name = doc.Name
 	ap.Version = doc.Version
 
-	comObjects := doc.Static.Objects
-	comObjectRefs := doc.Static.ObjectRefs
+	ap.Objects = make([]ComObject, len(doc.Static.Objects))
+	ap.ObjectRefs = make([]ComObjectRef, len(doc.Static.ObjectRefs))
+
+	for n, docComObj := range doc.Static.Objects {
+		ap.Objects[n] = ComObject(docComObj)
+	}
+
+	for n, docComObjRef := range doc.Static.ObjectRefs {
+		ap.ObjectRefs[n] = ComObjectRef(docComObjRef)
+	}
+
+	ap.ModuleDefs = make([]ModuleDef, len(doc.Modules))
+	for n, module := range doc.Modules {
+		md := ModuleDef{
+			ID:         ModuleID(programRelativeID(module.ID)),
+			Name:       module.Name,
+			Arguments:  make([]ModuleArgument, len(module.Arguments)),
+			Objects:    make([]ComObject, len(module.Objects)),
+			ObjectRefs: make([]ComObjectRef, len(module.ObjectRefs)),
+			Dynamic:    make([]DynamicNode, len(module.Dynamic.Nodes)),
+		}
+
+		for i, arg := range module.Arguments {
+			md.Arguments[i] = ModuleArgument{
+				ID:   programRelativeID(arg.ID),
+				Name: arg.Name,
+			}
+		}
+
+		for i, docComObj := range module.Objects {
+			md.Objects[i] = ComObject(docComObj)
+		}
+
+		for i, docComObjRef := range module.ObjectRefs {
+			md.ObjectRefs[i] = ComObjectRef(docComObjRef)
+		}
+
+		for i, docNode := range module.Dynamic.Nodes {
+			md.Dynamic[i] = DynamicNode(docNode)
+		}
+
+		ap.ModuleDefs[n] = md
+	}
+
+	ap.ParameterTypes = make([]ParameterType, len(doc.Static.ParameterTypes))
+	parameterTypes := map[ParameterTypeID]ParameterType{}
+	for n, docParamType := range doc.Static.ParameterTypes {
+		ap.ParameterTypes[n] = ParameterType(docParamType)
+		parameterTypes[ap.ParameterTypes[n].ID] = ap.ParameterTypes[n]
+	}
+
+	parameters := append(doc.Static.Parameters, doc.Static.UnionParameters...)
+	parameterRefs := doc.Static.ParameterRefs
 	for _, module := range doc.Modules {
-		comObjects = append(comObjects, module.Objects[:]...)
-		comObjectRefs = append(comObjectRefs, module.ObjectRefs[:]...)
+		parameters = append(parameters, module.Parameters...)
+		parameters = append(parameters, module.UnionParameters...)
+		parameterRefs = append(parameterRefs, module.ParameterRefs...)
 	}
 
-	ap.Objects = make([]ComObject, len(comObjects))
-	ap.ObjectRefs = make([]ComObjectRef, len(comObjectRefs))
+	ap.Parameters = make([]Parameter, len(parameters))
+	for n, docParam := range parameters {
+		ap.Parameters[n] = docParam.Parameter
+		ap.Parameters[n].Type = parameterTypes[docParam.TypeID]
+	}
 
-	for n, docComObj := range comObjects {
-		ap.Objects[n] = ComObject(docComObj)
+	ap.ParameterRefs = make([]ParameterRef, len(parameterRefs))
+	for n, docParamRef := range parameterRefs {
+		ap.ParameterRefs[n] = ParameterRef(docParamRef)
 	}
 
-	for n, docComObjRef := range comObjectRefs {
-		ap.ObjectRefs[n] = ComObjectRef(docComObjRef)
+	if doc.Dynamic != nil {
+		ap.Dynamic = make([]DynamicNode, len(doc.Dynamic.Nodes))
+		for n, docNode := range doc.Dynamic.Nodes {
+			ap.Dynamic[n] = DynamicNode(docNode)
+		}
 	}
 
+	// The communication objects of modules are instantiated for every
+	// Module element of the Dynamic section.
+	ap.ModuleInstances = moduleInstances(ap.Dynamic)
+	objs, refs := (*ApplicationProgram)(ap).instantiateModules(ap.ModuleInstances)
+	ap.Objects = append(ap.Objects, objs...)
+	ap.ObjectRefs = append(ap.ObjectRefs, refs...)
+
 	return nil
 }
 
@@ -554,8 +913,11 @@ type product11 Product
 
 func (pr *product11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID   string `xml:"Id,attr"`
-		Text string `xml:",attr"`
+		ID                string  `xml:"Id,attr"`
+		Text              string  `xml:",attr"`
+		OrderNumber       string  `xml:",attr"`
+		IsRailMounted     bool    `xml:",attr"`
+		WidthInMillimeter float64 `xml:",attr"`
 	}
 
 	// <Product Id="M-0080_H-2014.5F10.5F14-1_P-EB10430442"
@@ -571,6 +933,9 @@ func (pr *product11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 	pr.HardwareID = HardwareID(ids[1])
 	pr.ID = ProductID(ids[2])
 	pr.Text = doc.Text
+	pr.OrderNumber = doc.OrderNumber
+	pr.IsRailMounted = doc.IsRailMounted
+	pr.WidthInMillimeter = doc.WidthInMillimeter
 
 	return nil
 }
@@ -580,6 +945,10 @@ type hardware2Program11 Hardware2Program
 func (hp *hardware2Program11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
 		ID          string `xml:"Id,attr"`
+		MediumTypes string `xml:",attr"`
+		Hash        string `xml:",attr"`
+		Checksum    string `xml:",attr"`
+		LoaderHash  string `xml:",attr"`
 		ProgramRefs []struct {
 			RefID string `xml:"RefId,attr"`
 		} `xml:"ApplicationProgramRef"`
@@ -604,6 +973,15 @@ func (hp *hardware2Program11) UnmarshalXML(d *xml.Decoder, start xml.StartElemen
 		}
 	}
 
+	// MediumTypes is a space-separated list, e.g. "MT-0 MT-5"
+	for _, mt := range strings.Fields(doc.MediumTypes) {
+		hp.MediumTypes = append(hp.MediumTypes, MediumTypeID(mt))
+	}
+
+	hp.Hash = doc.Hash
+	hp.Checksum = doc.Checksum
+	hp.LoaderHash = doc.LoaderHash
+
 	return nil
 }
 
@@ -611,28 +989,49 @@ type hardware11 Hardware
 
 func (hw *hardware11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		Hardware struct {
-			ID                string               `xml:"RefId,attr"`
-			Name              string               `xml:",attr`
-			Products          []product11          `xml:"Products>Product"`
-			Hardware2Programs []hardware2Program11 `xml:"Hardware2Programs>Hardware2Program"`
-		} `xml:"Hardware"`
-	}
-
+		ID                    string               `xml:"Id,attr"`
+		Name                  string               `xml:",attr"`
+		SerialNumber          string               `xml:",attr"`
+		VersionNumber         uint                 `xml:",attr"`
+		BusCurrent            float64              `xml:",attr"`
+		IsPowerSupply         bool                 `xml:",attr"`
+		IsCoupler             bool                 `xml:",attr"`
+		IsIPEnabled           bool                 `xml:",attr"`
+		HasIndividualAddress  bool                 `xml:",attr"`
+		HasApplicationProgram bool                 `xml:",attr"`
+		Products              []product11          `xml:"Products>Product"`
+		Hardware2Programs     []hardware2Program11 `xml:"Hardware2Programs>Hardware2Program"`
+	}
+
+	// <Hardware Id="M-0083_H-4-2"
 	if err := d.DecodeElement(&doc, &start); err != nil {
 		return err
 	}
 
-	hw.ID = HardwareID(doc.Hardware.ID)
-	hw.Name = doc.Hardware.Name
-	hw.Products = make([]Product, len(doc.Hardware.Products))
-	hw.Hardware2Programs = make([]Hardware2Program, len(doc.Hardware.Hardware2Programs))
-
-	for n, docProd := range doc.Hardware.Products {
+	ids := strings.Split(doc.ID, "_")
+	if len(ids) != 2 {
+		return fmt.Errorf("Invalid Hardware Id %s", doc.ID)
+	}
+
+	hw.ManufacturerID = ManufacturerID(ids[0])
+	hw.ID = HardwareID(ids[1])
+	hw.Name = doc.Name
+	hw.SerialNumber = doc.SerialNumber
+	hw.VersionNumber = doc.VersionNumber
+	hw.BusCurrent = doc.BusCurrent
+	hw.IsPowerSupply = doc.IsPowerSupply
+	hw.IsCoupler = doc.IsCoupler
+	hw.IsIPEnabled = doc.IsIPEnabled
+	hw.HasIndividualAddress = doc.HasIndividualAddress
+	hw.HasApplicationProgram = doc.HasApplicationProgram
+	hw.Products = make([]Product, len(doc.Products))
+	hw.Hardware2Programs = make([]Hardware2Program, len(doc.Hardware2Programs))
+
+	for n, docProd := range doc.Products {
 		hw.Products[n] = Product(docProd)
 	}
 
-	for n, docProg := range doc.Hardware.Hardware2Programs {
+	for n, docProg := range doc.Hardware2Programs {
 		hw.Hardware2Programs[n] = Hardware2Program(docProg)
 	}
 
@@ -687,7 +1086,7 @@ func (md *hardwareData11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 	var doc struct {
 		Manufacturer struct {
 			ID        string       `xml:"RefId,attr"`
-			Hardwares []hardware11 `xml:"Hardware"`
+			Hardwares []hardware11 `xml:"Hardware>Hardware"`
 			Languages []language11 `xml:"Languages>Language"`
 		} `xml:"ManufacturerData>Manufacturer"`
 	}
@@ -710,3 +1109,324 @@ func (md *hardwareData11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) e
 
 	return nil
 }
+
+type catalogItem11 CatalogItem
+
+func (ci *catalogItem11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID                    string `xml:"Id,attr"`
+		Name                  string `xml:",attr"`
+		Number                string `xml:",attr"`
+		VisibleDescription    string `xml:",attr"`
+		ProductRefID          string `xml:"ProductRefId,attr"`
+		Hardware2ProgramRefID string `xml:"Hardware2ProgramRefId,attr"`
+	}
+
+	// <CatalogItem Id="M-0083_H-4-2_HP-0019-21-D29E_CI-AMS.2D1216.2E02-1"
+	// ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E"
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	ids := strings.Split(doc.ProductRefID, "_")
+	if len(ids) != 3 {
+		return fmt.Errorf("Invalid Product RefId %s", doc.ProductRefID)
+	}
+
+	ci.ManufacturerID = ManufacturerID(ids[0])
+	ci.HardwareID = HardwareID(ids[1])
+	ci.ProductID = ProductID(ids[2])
+
+	if ids := strings.Split(doc.Hardware2ProgramRefID, "_"); len(ids) == 3 {
+		ci.Hardware2ProgramID = Hardware2ProgramID(ids[2])
+	}
+
+	ids = strings.Split(doc.ID, "_")
+	ci.ID = CatalogItemID(ids[len(ids)-1])
+	ci.Name = doc.Name
+	ci.Number = doc.Number
+	ci.VisibleDescription = doc.VisibleDescription
+
+	return nil
+}
+
+type catalogSection11 CatalogSection
+
+func (cs *catalogSection11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		ID       string             `xml:"Id,attr"`
+		Name     string             `xml:",attr"`
+		Number   string             `xml:",attr"`
+		Sections []catalogSection11 `xml:"CatalogSection"`
+		Items    []catalogItem11    `xml:"CatalogItem"`
+	}
+
+	// <CatalogSection Id="M-0083_CS-A.2001-S.2003"
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	ids := strings.Split(doc.ID, "_")
+	cs.ID = CatalogSectionID(ids[len(ids)-1])
+	cs.Name = doc.Name
+	cs.Number = doc.Number
+	cs.Sections = make([]CatalogSection, len(doc.Sections))
+	cs.Items = make([]CatalogItem, len(doc.Items))
+
+	for n, docSection := range doc.Sections {
+		cs.Sections[n] = CatalogSection(docSection)
+	}
+
+	for n, docItem := range doc.Items {
+		cs.Items[n] = CatalogItem(docItem)
+	}
+
+	return nil
+}
+
+type catalog11 Catalog
+
+func (c *catalog11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		Manufacturer struct {
+			ID        string             `xml:"RefId,attr"`
+			Sections  []catalogSection11 `xml:"Catalog>CatalogSection"`
+			Languages []language11       `xml:"Languages>Language"`
+		} `xml:"ManufacturerData>Manufacturer"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	c.Manufacturer = ManufacturerID(doc.Manufacturer.ID)
+	c.Sections = make([]CatalogSection, len(doc.Manufacturer.Sections))
+	c.Languages = make([]Language, len(doc.Manufacturer.Languages))
+
+	for n, docSection := range doc.Manufacturer.Sections {
+		c.Sections[n] = CatalogSection(docSection)
+	}
+
+	for n, lang := range doc.Manufacturer.Languages {
+		c.Languages[n] = Language(lang)
+	}
+
+	return nil
+}
+
+type masterData11 MasterData
+
+func (m *masterData11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		MasterData struct {
+			Version       string `xml:",attr"`
+			Manufacturers []struct {
+				ID                string `xml:"Id,attr"`
+				KnxManufacturerID uint   `xml:"KnxManufacturerId,attr"`
+				Name              string `xml:",attr"`
+				DefaultLanguage   string `xml:",attr"`
+			} `xml:"Manufacturers>Manufacturer"`
+			DatapointTypes datapointTypes11
+			MediumTypes    []struct {
+				ID     string `xml:"Id,attr"`
+				Number uint   `xml:",attr"`
+				Name   string `xml:",attr"`
+				Text   string `xml:",attr"`
+			} `xml:"MediumTypes>MediumType"`
+			MaskVersions []struct {
+				ID              string `xml:"Id,attr"`
+				MaskVersion     uint   `xml:",attr"`
+				Name            string `xml:",attr"`
+				ManagementModel string `xml:",attr"`
+				MediumTypeRefID string `xml:"MediumTypeRefId,attr"`
+			} `xml:"MaskVersions>MaskVersion"`
+			Languages        []language11 `xml:"Languages>Language"`
+			ProductLanguages []struct {
+				ID string `xml:"Identifier,attr"`
+			} `xml:"ProductLanguages>Language"`
+		}
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	m.Version = doc.MasterData.Version
+	m.DatapointTypes = NewDatapointTypeRegistry(doc.MasterData.DatapointTypes)
+
+	m.Manufacturers = make([]MasterManufacturer, len(doc.MasterData.Manufacturers))
+	for n, docManu := range doc.MasterData.Manufacturers {
+		m.Manufacturers[n] = MasterManufacturer{
+			ID:                ManufacturerID(docManu.ID),
+			KnxManufacturerID: docManu.KnxManufacturerID,
+			Name:              docManu.Name,
+			DefaultLanguage:   LanguageID(docManu.DefaultLanguage),
+		}
+	}
+
+	m.MediumTypes = make([]MediumType, len(doc.MasterData.MediumTypes))
+	for n, docMedium := range doc.MasterData.MediumTypes {
+		m.MediumTypes[n] = MediumType{
+			ID:     MediumTypeID(docMedium.ID),
+			Number: docMedium.Number,
+			Name:   docMedium.Name,
+			Text:   docMedium.Text,
+		}
+	}
+
+	m.MaskVersions = make([]MaskVersion, len(doc.MasterData.MaskVersions))
+	for n, docMask := range doc.MasterData.MaskVersions {
+		m.MaskVersions[n] = MaskVersion{
+			ID:              MaskVersionID(docMask.ID),
+			MaskVersion:     docMask.MaskVersion,
+			Name:            docMask.Name,
+			ManagementModel: docMask.ManagementModel,
+			MediumTypeID:    MediumTypeID(docMask.MediumTypeRefID),
+		}
+	}
+
+	m.Languages = make([]Language, len(doc.MasterData.Languages))
+	for n, docLang := range doc.MasterData.Languages {
+		m.Languages[n] = Language(docLang)
+	}
+
+	m.ProductLanguages = make([]LanguageID, len(doc.MasterData.ProductLanguages))
+	for n, docLang := range doc.MasterData.ProductLanguages {
+		m.ProductLanguages[n] = LanguageID(docLang.ID)
+	}
+
+	return nil
+}
+
+type datapointTypeRegistry11 DatapointTypeRegistry
+
+func (r *datapointTypeRegistry11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		Types datapointTypes11 `xml:"MasterData>DatapointTypes"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	*r = datapointTypeRegistry11(*NewDatapointTypeRegistry(doc.Types))
+
+	return nil
+}
+
+// datapointTypes11 are the definitions of the datapoint types and subtypes of a DatapointTypes element.
+type datapointTypes11 []DatapointTypeDefinition
+
+func (dt *datapointTypes11) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type format struct {
+		Fields []struct {
+			XMLName      xml.Name
+			Width        int    `xml:",attr"`
+			Unit         string `xml:",attr"`
+			MinInclusive string `xml:",attr"`
+			MaxInclusive string `xml:",attr"`
+			MinValue     string `xml:",attr"`
+			MaxValue     string `xml:",attr"`
+			Coefficient  string `xml:",attr"`
+			Cleared      string `xml:",attr"`
+			Set          string `xml:",attr"`
+			EnumValues   []struct {
+				Value int    `xml:",attr"`
+				Text  string `xml:",attr"`
+			} `xml:"EnumValue"`
+		} `xml:",any"`
+	}
+
+	var doc struct {
+		Types []struct {
+			ID             string `xml:"Id,attr"`
+			Number         int    `xml:",attr"`
+			Name           string `xml:",attr"`
+			Text           string `xml:",attr"`
+			SizeInBit      int    `xml:",attr"`
+			VariableLength bool   `xml:",attr"`
+			Subtypes       []struct {
+				ID     string `xml:"Id,attr"`
+				Number int    `xml:",attr"`
+				Name   string `xml:",attr"`
+				Text   string `xml:",attr"`
+				Format format
+			} `xml:"DatapointSubtypes>DatapointSubtype"`
+		} `xml:"DatapointType"`
+	}
+
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+
+	var defs []DatapointTypeDefinition
+	for _, docType := range doc.Types {
+		defs = append(defs, DatapointTypeDefinition{
+			Type:           DatapointType{Main: docType.Number, Sub: NoSubtype},
+			Name:           docType.Name,
+			Text:           docType.Text,
+			SizeInBit:      docType.SizeInBit,
+			VariableLength: docType.VariableLength,
+		})
+
+		for _, docSubtype := range docType.Subtypes {
+			def := DatapointTypeDefinition{
+				Type:           DatapointType{Main: docType.Number, Sub: docSubtype.Number},
+				Name:           docSubtype.Name,
+				Text:           docSubtype.Text,
+				SizeInBit:      docType.SizeInBit,
+				VariableLength: docType.VariableLength,
+			}
+
+			// Units and ranges are only available for datapoint types with a single field.
+			if fields := docSubtype.Format.Fields; len(fields) == 1 {
+				field := fields[0]
+				def.Unit = field.Unit
+				def.Min = parseFloatAttr(field.MinInclusive, field.MinValue)
+				def.Max = parseFloatAttr(field.MaxInclusive, field.MaxValue)
+
+				switch field.XMLName.Local {
+				case "UnsignedInteger":
+					if def.Min == nil && def.Max == nil && field.Width > 0 && field.Width < 64 {
+						coef := 1.0
+						if c := parseFloatAttr(field.Coefficient); c != nil {
+							coef = *c
+						}
+						min, max := 0.0, float64(uint64(1)<<uint(field.Width)-1)*coef
+						def.Min, def.Max = &min, &max
+					}
+				case "Bit":
+					def.Values = []DatapointTypeValue{
+						{Value: 0, Text: field.Cleared},
+						{Value: 1, Text: field.Set},
+					}
+				case "Enumeration":
+					for _, v := range field.EnumValues {
+						def.Values = append(def.Values, DatapointTypeValue{Value: v.Value, Text: v.Text})
+					}
+				}
+			}
+
+			defs = append(defs, def)
+		}
+	}
+
+	*dt = defs
+
+	return nil
+}
+
+// parseFloatAttr returns the value of the first non-empty attribute as float.
+func parseFloatAttr(attrs ...string) *float64 {
+	for _, attr := range attrs {
+		if len(attr) == 0 {
+			continue
+		}
+
+		if f, err := strconv.ParseFloat(attr, 64); err == nil {
+			return &f
+		}
+	}
+
+	return nil
+}
@@ -12,14 +12,17 @@ type deviceInstance20 DeviceInstance
 
 func (di *deviceInstance20) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		ID         string `xml:"Id,attr"`
-		ProductID  string `xml:"ProductRefId,attr"`
-		ProgramID  string `xml:"Hardware2ProgramRefId,attr"`
-		Name       string `xml:",attr"`
-		Address    uint16 `xml:",attr"`
+		ID         string  `xml:"Id,attr"`
+		ProductID  string  `xml:"ProductRefId,attr"`
+		ProgramID  string  `xml:"Hardware2ProgramRefId,attr"`
+		Name       string  `xml:",attr"`
+		Address    *uint16 `xml:",attr"`
 		ComObjects []struct {
 			RefID             string `xml:"RefId,attr"`
+			Text              string `xml:",attr"`
+			FunctionText      string `xml:",attr"`
 			DatapointType     string `xml:",attr"`
+			Priority          string `xml:",attr"`
 			Links             string `xml:",attr"`
 			ReadFlag          string `xml:",attr"`
 			WriteFlag         string `xml:",attr"`
@@ -28,6 +31,18 @@ func (di *deviceInstance20) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 			UpdateFlag        string `xml:",attr"`
 			ReadOnInitFlag    string `xml:",attr"`
 		} `xml:"ComObjectInstanceRefs>ComObjectInstanceRef"`
+		Parameters []struct {
+			RefID string `xml:"RefId,attr"`
+			Value string `xml:",attr"`
+		} `xml:"ParameterInstanceRefs>ParameterInstanceRef"`
+		ModuleInstances []struct {
+			ID        string `xml:"Id,attr"`
+			RefID     string `xml:"RefId,attr"`
+			Arguments []struct {
+				RefID string `xml:"RefId,attr"`
+				Value string `xml:",attr"`
+			} `xml:"Arguments>Argument"`
+		} `xml:"ModuleInstances>ModuleInstance"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -53,7 +68,10 @@ func (di *deviceInstance20) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 	}
 
 	di.Name = doc.Name
-	di.Address = doc.Address
+	if doc.Address != nil {
+		di.Address = *doc.Address
+		di.hasAddress = true
+	}
 	di.ComObjects = make([]ComObjectInstanceRef, len(doc.ComObjects))
 
 	for n, docComObj := range doc.ComObjects {
@@ -75,26 +93,60 @@ func (di *deviceInstance20) UnmarshalXML(d *xml.Decoder, start xml.StartElement)
 		comObj := ComObjectInstanceRef{
 			ComObjectID:       comObjID,
 			ComObjectRefID:    comObjRefID,
+			ModuleInstanceID:  moduleInstanceID(docComObj.RefID),
+			Text:              docComObj.Text,
+			FunctionText:      docComObj.FunctionText,
 			DatapointType:     docComObj.DatapointType,
+			Priority:          docComObj.Priority,
 			Links:             make([]string, 0),
-			ReadFlag:          docComObj.ReadFlag == "Enabled",
-			WriteFlag:         docComObj.WriteFlag == "Enabled",
-			CommunicationFlag: docComObj.CommunicationFlag == "Enabled",
-			TransmitFlag:      docComObj.TransmitFlag == "Enabled",
-			UpdateFlag:        docComObj.UpdateFlag == "Enabled",
-			ReadOnInitFlag:    docComObj.ReadOnInitFlag == "Enabled",
+			Connectors:        make([]Connector, 0),
+			ReadFlag:          parseFlag(docComObj.ReadFlag),
+			WriteFlag:         parseFlag(docComObj.WriteFlag),
+			CommunicationFlag: parseFlag(docComObj.CommunicationFlag),
+			TransmitFlag:      parseFlag(docComObj.TransmitFlag),
+			UpdateFlag:        parseFlag(docComObj.UpdateFlag),
+			ReadOnInitFlag:    parseFlag(docComObj.ReadOnInitFlag),
 		}
 
+		// The first link is the sending group address.
 		links := strings.Split(docComObj.Links, " ")
 		for _, link := range links {
 			if len(link) > 0 {
 				comObj.Links = append(comObj.Links, link)
+				comObj.Connectors = append(comObj.Connectors, Connector{
+					Receive: len(comObj.Connectors) > 0,
+					RefID:   GroupAddressID(link),
+				})
 			}
 		}
 
 		di.ComObjects[n] = comObj
 	}
 
+	di.Parameters = make([]ParameterInstanceRef, len(doc.Parameters))
+	for n, docParam := range doc.Parameters {
+		di.Parameters[n] = ParameterInstanceRef{
+			RefID: ParameterRefID(programRelativeID(docParam.RefID)),
+			Value: docParam.Value,
+		}
+	}
+
+	di.ModuleInstances = make([]ModuleInstance, len(doc.ModuleInstances))
+	for n, docModule := range doc.ModuleInstances {
+		mi := ModuleInstance{
+			ID:          ModuleInstanceID(programRelativeID(docModule.ID)),
+			ModuleDefID: ModuleID(programRelativeID(docModule.RefID)),
+			Arguments:   make([]ModuleArgumentValue, len(docModule.Arguments)),
+		}
+		for i, arg := range docModule.Arguments {
+			mi.Arguments[i] = ModuleArgumentValue{
+				ArgumentID: programRelativeID(arg.RefID),
+				Value:      arg.Value,
+			}
+		}
+		di.ModuleInstances[n] = mi
+	}
+
 	return nil
 }
 
@@ -155,6 +207,7 @@ func (a *area20) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	for n, docLine := range doc.Line {
 		a.Lines[n] = Line(docLine)
 	}
+	(*Area)(a).setIndividualAddresses()
 
 	return nil
 }
@@ -163,10 +216,11 @@ type installation20 Installation
 
 func (i *installation20) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		Name        string         `xml:",attr"`
-		Areas       []area20       `xml:"Topology>Area"`
-		GroupRanges []groupRange11 `xml:"GroupAddresses>GroupRanges>GroupRange"`
-		Locations   []space11      `xml:"Locations>Space"`
+		Name              string             `xml:",attr"`
+		Areas             []area20           `xml:"Topology>Area"`
+		UnassignedDevices []deviceInstance20 `xml:"Topology>UnassignedDevices>DeviceInstance"`
+		GroupRanges       []groupRange11     `xml:"GroupAddresses>GroupRanges>GroupRange"`
+		Locations         []space11          `xml:"Locations>Space"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -182,6 +236,11 @@ func (i *installation20) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		i.Topology[n] = Area(docArea)
 	}
 
+	i.UnassignedDevices = make([]DeviceInstance, len(doc.UnassignedDevices))
+	for n, docDevice := range doc.UnassignedDevices {
+		i.UnassignedDevices[n] = DeviceInstance(docDevice)
+	}
+
 	for n, docGrpRange := range doc.GroupRanges {
 		i.GroupAddresses[n] = GroupRange(docGrpRange)
 	}
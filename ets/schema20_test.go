@@ -1,8 +1,10 @@
 package ets
 
 import (
-	"github.com/go-test/deep"
+	"strings"
 	"testing"
+
+	"github.com/go-test/deep"
 )
 
 func TestVersion5_7_2_743(t *testing.T) {
@@ -56,13 +58,14 @@ func TestVersion5_7_2_743(t *testing.T) {
 				},
 			},
 			Area{
-				ID:        AreaID("A-2"),
-				ProjectID: ProjectID("P-0497-0"),
-				Name:      "New area",
-				Address:   1,
+				ID:                AreaID("A-2"),
+				ProjectID:         ProjectID("P-0497-0"),
+				Name:              "New area",
+				Address:           1,
+				IndividualAddress: NewIndividualAddress(1, 0, 0),
 				Lines: []Line{
-					Line{ID: LineID("L-2"), ProjectID: ProjectID("P-0497-0"), Name: "Main line", Address: 0, Devices: []DeviceInstance{}},
-					Line{ID: LineID("L-3"), ProjectID: ProjectID("P-0497-0"), Name: "New line", Address: 1, Devices: []DeviceInstance{
+					Line{ID: LineID("L-2"), ProjectID: ProjectID("P-0497-0"), Name: "Main line", Address: 0, IndividualAddress: NewIndividualAddress(1, 0, 0), Devices: []DeviceInstance{}},
+					Line{ID: LineID("L-3"), ProjectID: ProjectID("P-0497-0"), Name: "New line", Address: 1, IndividualAddress: NewIndividualAddress(1, 1, 0), Devices: []DeviceInstance{
 						DeviceInstance{
 							ID:                 DeviceInstanceID("DI-1"),
 							ProjectID:          ProjectID("P-0497-0"),
@@ -72,14 +75,18 @@ func TestVersion5_7_2_743(t *testing.T) {
 							Hardware2ProgramID: Hardware2ProgramID("HP-3120-32-269B-3120-42-4C77"),
 							Name:               "",
 							Address:            1,
+							IndividualAddress:  newIndividualAddress(1, 1, 1),
 							ComObjects: []ComObjectInstanceRef{
 								ComObjectInstanceRef{
 									ComObjectRefID: ComObjectRefID("R-1"),
 									ComObjectID:    ComObjectID("O-10"),
 									DatapointType:  "",
 									Links:          []string{"GA-1"},
+									Connectors:     []Connector{Connector{RefID: GroupAddressID("GA-1")}},
 								},
 							},
+							Parameters:      []ParameterInstanceRef{},
+							ModuleInstances: []ModuleInstance{},
 						},
 						DeviceInstance{
 							ID:                 DeviceInstanceID("DI-2"),
@@ -90,14 +97,18 @@ func TestVersion5_7_2_743(t *testing.T) {
 							Hardware2ProgramID: Hardware2ProgramID("HP-0019-21-D29E"),
 							Name:               "",
 							Address:            2,
+							IndividualAddress:  newIndividualAddress(1, 1, 2),
 							ComObjects: []ComObjectInstanceRef{
 								ComObjectInstanceRef{
 									ComObjectRefID: ComObjectRefID("R-10000"),
 									ComObjectID:    ComObjectID("O-0"),
 									DatapointType:  "",
 									Links:          []string{"GA-1"},
+									Connectors:     []Connector{Connector{RefID: GroupAddressID("GA-1")}},
 								},
 							},
+							Parameters:      []ParameterInstanceRef{},
+							ModuleInstances: []ModuleInstance{},
 						},
 					}},
 				},
@@ -232,3 +243,54 @@ func TestVersion5_7_2_743(t *testing.T) {
 		t.Fatalf("%v != %v", is, want)
 	}
 }
+
+func TestConnectors(t *testing.T) {
+	// Schema 11 uses Send and Receive elements, schema 20 lists the sending group address first.
+	tests := []struct {
+		ns         string
+		links      string
+		connectors string
+	}{
+		{"11", "", `<Connectors><Send GroupAddressRefId="P-0001-0_GA-2" /><Receive GroupAddressRefId="P-0001-0_GA-1" /></Connectors>`},
+		{"20", `Links="GA-2 GA-1"`, ""},
+	}
+
+	for _, test := range tests {
+		proj, err := DecodeProject(strings.NewReader(`<KNX xmlns="http://knx.org/xml/project/` + test.ns + `">
+  <Project Id="P-0001">
+    <Installations>
+      <Installation Name="">
+        <Topology>
+          <Area Id="P-0001-0_A-1" Address="1">
+            <Line Id="P-0001-0_L-1" Address="1">
+              <DeviceInstance Id="P-0001-0_DI-1" Address="1" ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E">
+                <ComObjectInstanceRefs>
+                  <ComObjectInstanceRef RefId="O-0_R-1" ` + test.links + `>` + test.connectors + `</ComObjectInstanceRef>
+                </ComObjectInstanceRefs>
+              </DeviceInstance>
+            </Line>
+          </Area>
+        </Topology>
+      </Installation>
+    </Installations>
+  </Project>
+</KNX>`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref := proj.Installations[0].Topology[0].Lines[0].Devices[0].ComObjects[0]
+		want := []Connector{
+			Connector{Receive: false, RefID: "GA-2"},
+			Connector{Receive: true, RefID: "GA-1"},
+		}
+
+		if diff := deep.Equal(ref.Connectors, want); diff != nil {
+			t.Fatalf("%s: %v", test.ns, diff)
+		}
+
+		if id, _ := ref.SendingGroupAddress(); id != "GA-2" {
+			t.Fatalf("%s: %v != %v", test.ns, id, "GA-2")
+		}
+	}
+}
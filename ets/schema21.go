@@ -15,15 +15,17 @@ func (a *area21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		Name    string `xml:",attr"`
 		Address uint16 `xml:",attr"`
 		Line    []struct {
-			ID      string `xml:"Id,attr"`
-			Name    string `xml:",attr"`
-			Address uint16 `xml:",attr"`
-			Segment struct {
-				ID             string `xml:"Id,attr"`
-				Name           string `xml:",attr"`
-				Number         int    `xml:",attr"`
-				DeviceInstance []deviceInstance20
-			}
+			ID       string `xml:"Id,attr"`
+			Name     string `xml:",attr"`
+			Address  uint16 `xml:",attr"`
+			Segments []struct {
+				ID              string `xml:"Id,attr"`
+				Name            string `xml:",attr"`
+				Number          int    `xml:",attr"`
+				MediumTypeRefID string `xml:"MediumTypeRefId,attr"`
+				DomainAddress   string `xml:",attr"`
+				DeviceInstance  []deviceInstance20
+			} `xml:"Segment"`
 		}
 	}
 
@@ -42,9 +44,10 @@ func (a *area21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 	for n, docLine := range doc.Line {
 		line := Line{
-			Name:    docLine.Name,
-			Address: docLine.Address,
-			Devices: make([]DeviceInstance, len(docLine.Segment.DeviceInstance)),
+			Name:     docLine.Name,
+			Address:  docLine.Address,
+			Devices:  []DeviceInstance{},
+			Segments: make([]Segment, len(docLine.Segments)),
 		}
 
 		ids := strings.Split(docLine.ID, "_")
@@ -53,11 +56,35 @@ func (a *area21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 			line.ID = LineID(ids[1])
 		}
 
-		for n, segmentDevice := range docLine.Segment.DeviceInstance {
-			line.Devices[n] = DeviceInstance(segmentDevice)
+		for _, docSegment := range docLine.Segments {
+			for _, segmentDevice := range docSegment.DeviceInstance {
+				line.Devices = append(line.Devices, DeviceInstance(segmentDevice))
+			}
+		}
+
+		// The devices of a segment are a slice of the line's devices.
+		offset := 0
+		for i, docSegment := range docLine.Segments {
+			end := offset + len(docSegment.DeviceInstance)
+			segment := Segment{
+				Name:          docSegment.Name,
+				Number:        docSegment.Number,
+				MediumTypeID:  MediumTypeID(docSegment.MediumTypeRefID),
+				DomainAddress: docSegment.DomainAddress,
+				Devices:       line.Devices[offset:end:end],
+			}
+			offset = end
+
+			ids := strings.Split(docSegment.ID, "_")
+			if len(ids) == 2 {
+				segment.ProjectID = ProjectID(ids[0])
+				segment.ID = SegmentID(ids[1])
+			}
+			line.Segments[i] = segment
 		}
 		a.Lines[n] = line
 	}
+	(*Area)(a).setIndividualAddresses()
 
 	return nil
 }
@@ -66,10 +93,11 @@ type installation21 Installation
 
 func (i *installation21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	var doc struct {
-		Name        string         `xml:",attr"`
-		Areas       []area21       `xml:"Topology>Area"`
-		GroupRanges []groupRange11 `xml:"GroupAddresses>GroupRanges>GroupRange"`
-		Locations   []space11      `xml:"Locations>Space"`
+		Name              string             `xml:",attr"`
+		Areas             []area21           `xml:"Topology>Area"`
+		UnassignedDevices []deviceInstance20 `xml:"Topology>UnassignedDevices>DeviceInstance"`
+		GroupRanges       []groupRange11     `xml:"GroupAddresses>GroupRanges>GroupRange"`
+		Locations         []space11          `xml:"Locations>Space"`
 	}
 
 	if err := d.DecodeElement(&doc, &start); err != nil {
@@ -85,6 +113,11 @@ func (i *installation21) UnmarshalXML(d *xml.Decoder, start xml.StartElement) er
 		i.Topology[n] = Area(docArea)
 	}
 
+	i.UnassignedDevices = make([]DeviceInstance, len(doc.UnassignedDevices))
+	for n, docDevice := range doc.UnassignedDevices {
+		i.UnassignedDevices[n] = DeviceInstance(docDevice)
+	}
+
 	for n, docGrpRange := range doc.GroupRanges {
 		i.GroupAddresses[n] = GroupRange(docGrpRange)
 	}
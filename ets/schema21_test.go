@@ -1,6 +1,7 @@
 package ets
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -53,17 +54,22 @@ func TestVersion6_0(t *testing.T) {
 				Name:      "Backbone area",
 				Address:   0,
 				Lines: []Line{
-					Line{ID: LineID("L-1"), ProjectID: ProjectID("P-0497-0"), Name: "Backbone line", Address: 0, Devices: []DeviceInstance{}},
+					Line{ID: LineID("L-1"), ProjectID: ProjectID("P-0497-0"), Name: "Backbone line", Address: 0, Devices: []DeviceInstance{}, Segments: []Segment{
+						Segment{ID: SegmentID("S-1"), ProjectID: ProjectID("P-0497-0"), Name: "Main segment", MediumTypeID: MediumTypeID("MT-5"), Devices: []DeviceInstance{}},
+					}},
 				},
 			},
 			Area{
-				ID:        AreaID("A-2"),
-				ProjectID: ProjectID("P-0497-0"),
-				Name:      "New area",
-				Address:   1,
+				ID:                AreaID("A-2"),
+				ProjectID:         ProjectID("P-0497-0"),
+				Name:              "New area",
+				Address:           1,
+				IndividualAddress: NewIndividualAddress(1, 0, 0),
 				Lines: []Line{
-					Line{ID: LineID("L-2"), ProjectID: ProjectID("P-0497-0"), Name: "Main line", Address: 0, Devices: []DeviceInstance{}},
-					Line{ID: LineID("L-3"), ProjectID: ProjectID("P-0497-0"), Name: "New line", Address: 1, Devices: []DeviceInstance{
+					Line{ID: LineID("L-2"), ProjectID: ProjectID("P-0497-0"), Name: "Main line", Address: 0, IndividualAddress: NewIndividualAddress(1, 0, 0), Devices: []DeviceInstance{}, Segments: []Segment{
+						Segment{ID: SegmentID("S-2"), ProjectID: ProjectID("P-0497-0"), Name: "Main segment", MediumTypeID: MediumTypeID("MT-5"), Devices: []DeviceInstance{}},
+					}},
+					Line{ID: LineID("L-3"), ProjectID: ProjectID("P-0497-0"), Name: "New line", Address: 1, IndividualAddress: NewIndividualAddress(1, 1, 0), Devices: []DeviceInstance{
 						DeviceInstance{
 							ID:                 DeviceInstanceID("DI-1"),
 							ProjectID:          ProjectID("P-0497-0"),
@@ -73,14 +79,18 @@ func TestVersion6_0(t *testing.T) {
 							Hardware2ProgramID: Hardware2ProgramID("HP-3120-32-269B-3120-42-4C77"),
 							Name:               "",
 							Address:            1,
+							IndividualAddress:  newIndividualAddress(1, 1, 1),
 							ComObjects: []ComObjectInstanceRef{
 								ComObjectInstanceRef{
 									ComObjectRefID: ComObjectRefID("R-1"),
 									ComObjectID:    ComObjectID("O-10"),
 									DatapointType:  "",
 									Links:          []string{"GA-1"},
+									Connectors:     []Connector{Connector{RefID: GroupAddressID("GA-1")}},
 								},
 							},
+							Parameters:      []ParameterInstanceRef{},
+							ModuleInstances: []ModuleInstance{},
 						},
 						DeviceInstance{
 							ID:                 DeviceInstanceID("DI-2"),
@@ -91,20 +101,29 @@ func TestVersion6_0(t *testing.T) {
 							Hardware2ProgramID: Hardware2ProgramID("HP-0019-21-D29E"),
 							Name:               "",
 							Address:            2,
+							IndividualAddress:  newIndividualAddress(1, 1, 2),
 							ComObjects: []ComObjectInstanceRef{
 								ComObjectInstanceRef{
 									ComObjectRefID: ComObjectRefID("R-10000"),
 									ComObjectID:    ComObjectID("O-0"),
 									DatapointType:  "",
 									Links:          []string{"GA-1"},
+									Connectors:     []Connector{Connector{RefID: GroupAddressID("GA-1")}},
 								},
 							},
+							Parameters:      []ParameterInstanceRef{},
+							ModuleInstances: []ModuleInstance{},
 						},
 					}},
 				},
 			},
 		}
 
+		line := &areas[1].Lines[1]
+		line.Segments = []Segment{
+			Segment{ID: SegmentID("S-3"), ProjectID: ProjectID("P-0497-0"), Name: "Main segment", MediumTypeID: MediumTypeID("MT-0"), Devices: line.Devices},
+		}
+
 		if diff := deep.Equal(inst.Topology, areas); diff != nil {
 			t.Error(diff)
 		}
@@ -233,3 +252,97 @@ func TestVersion6_0(t *testing.T) {
 		t.Fatalf("%v != %v", is, want)
 	}
 }
+
+func TestSegments(t *testing.T) {
+	proj, err := DecodeProject(strings.NewReader(`<KNX xmlns="http://knx.org/xml/project/21">
+  <Project Id="P-0001">
+    <Installations>
+      <Installation Name="">
+        <Topology>
+          <Area Id="P-0001-0_A-1" Address="1" Name="Area">
+            <Line Id="P-0001-0_L-1" Address="1" Name="Line">
+              <Segment Id="P-0001-0_S-1" Name="Main segment" Number="0" MediumTypeRefId="MT-0">
+                <DeviceInstance Id="P-0001-0_DI-1" Address="1" ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E" />
+              </Segment>
+              <Segment Id="P-0001-0_S-2" Name="RF segment" Number="1" MediumTypeRefId="MT-2" DomainAddress="00FA12345678">
+                <DeviceInstance Id="P-0001-0_DI-2" Address="2" ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E" />
+                <DeviceInstance Id="P-0001-0_DI-3" Address="3" ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E" />
+              </Segment>
+            </Line>
+          </Area>
+        </Topology>
+      </Installation>
+    </Installations>
+  </Project>
+</KNX>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := &proj.Installations[0].Topology[0].Lines[0]
+	if is, want := len(line.Devices), 3; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(line.Segments), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	rf := line.Segments[1]
+	if is, want := rf.DomainAddress, "00FA12345678"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(rf.Devices), 2; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := rf.Devices[1].IndividualAddress.String(), "1.1.3"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := line.Segment("DI-2"), &line.Segments[1]; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
+
+func TestUnassignedDevices(t *testing.T) {
+	proj, err := DecodeProject(strings.NewReader(`<KNX xmlns="http://knx.org/xml/project/21">
+  <Project Id="P-0001">
+    <Installations>
+      <Installation Name="">
+        <Topology>
+          <UnassignedDevices>
+            <DeviceInstance Id="P-0001-0_DI-4" Address="7" ProductRefId="M-0083_H-4-2_P-AMS.2D1216.2E02" Hardware2ProgramRefId="M-0083_H-4-2_HP-0019-21-D29E" />
+          </UnassignedDevices>
+        </Topology>
+      </Installation>
+    </Installations>
+  </Project>
+</KNX>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices := proj.Installations[0].UnassignedDevices
+	if is, want := len(devices), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := devices[0].ID, DeviceInstanceID("DI-4"); is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if devices[0].IndividualAddress != nil {
+		t.Fatalf("Unexpected individual address %v", devices[0].IndividualAddress)
+	}
+
+	rp := Resolve(proj, nil, nil)
+	if is, want := len(rp.Devices), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if rp.Devices[0].Line != nil {
+		t.Fatal("Unexpected line")
+	}
+}
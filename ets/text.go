@@ -0,0 +1,139 @@
+package ets
+
+import (
+	"strings"
+)
+
+// TextResolver substitutes the placeholders in texts of an application program
+// as ETS does for a device. The placeholders "{{0}}" and "{{0:default}}" are replaced
+// with the value of the text parameter (TextParameterRefId) and "{{name}}" and
+// "{{name:default}}" with the value of a module argument.
+type TextResolver struct {
+	values map[ParameterRefID]string
+	params map[ParameterRefID]*Parameter
+	args   map[ModuleInstanceID]map[string]string
+}
+
+// NewTextResolver returns a text resolver for the parameter values and module instances
+// of a device. The device may be nil to resolve the texts with default values.
+func NewTextResolver(ap *ApplicationProgram, di *DeviceInstance) *TextResolver {
+	r := &TextResolver{
+		values: ap.ParameterValues(di),
+		params: map[ParameterRefID]*Parameter{},
+		args:   map[ModuleInstanceID]map[string]string{},
+	}
+
+	params := make(map[ParameterID]*Parameter, len(ap.Parameters))
+	for i := range ap.Parameters {
+		params[ap.Parameters[i].ID] = &ap.Parameters[i]
+	}
+
+	for _, ref := range ap.ParameterRefs {
+		if p, ok := params[ref.ParameterID]; ok {
+			r.params[ref.ID] = p
+		}
+	}
+
+	instances := ap.ModuleInstances
+	if di != nil {
+		instances = append(instances[:len(instances):len(instances)], di.ModuleInstances...)
+	}
+
+	for _, mi := range instances {
+		if md, ok := ap.ModuleDef(mi.ModuleDefID); ok {
+			r.args[mi.ID] = md.ArgumentValues(mi)
+		}
+	}
+
+	return r
+}
+
+// Resolve returns the text with its placeholders substituted. The text parameter and
+// module instance are empty if the text does not belong to them.
+func (r *TextResolver) Resolve(text string, textParam ParameterRefID, mi ModuleInstanceID) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	args := map[string]string{}
+	for name, value := range r.args[mi] {
+		args[name] = value
+	}
+
+	if v, ok := r.parameterText(textParam, mi); ok && len(v) > 0 {
+		args["0"] = v
+	}
+
+	return substituteArguments(text, args)
+}
+
+// ComObjectText returns the text of a communication object reference or, if empty,
+// of its communication object with substituted placeholders.
+func (r *TextResolver) ComObjectText(ref *ComObjectRef, obj *ComObject) string {
+	ref, obj = nonNilComObject(ref, obj)
+	textParam, mi := comObjectTextContext(ref, obj)
+
+	return r.Resolve(inheritString(ref.Text, obj.Text), textParam, mi)
+}
+
+// ComObjectFunctionText returns the function text of a communication object reference or,
+// if empty, of its communication object with substituted placeholders.
+func (r *TextResolver) ComObjectFunctionText(ref *ComObjectRef, obj *ComObject) string {
+	ref, obj = nonNilComObject(ref, obj)
+	textParam, mi := comObjectTextContext(ref, obj)
+
+	return r.Resolve(inheritString(ref.FunctionText, obj.FunctionText), textParam, mi)
+}
+
+func nonNilComObject(ref *ComObjectRef, obj *ComObject) (*ComObjectRef, *ComObject) {
+	if ref == nil {
+		ref = &ComObjectRef{}
+	}
+
+	if obj == nil {
+		obj = &ComObject{}
+	}
+
+	return ref, obj
+}
+
+// comObjectTextContext returns the text parameter and module instance of a communication object.
+func comObjectTextContext(ref *ComObjectRef, obj *ComObject) (ParameterRefID, ModuleInstanceID) {
+	textParam := ParameterRefID(inheritString(string(ref.TextParameterRefID), string(obj.TextParameterRefID)))
+	mi := ModuleInstanceID(inheritString(string(ref.ModuleInstanceID), string(obj.ModuleInstanceID)))
+
+	return textParam, mi
+}
+
+// parameterText returns the value of the parameter reference as text. The value of
+// an enumerated parameter is its enumeration text. Parameters of module instances
+// are looked up by the id of the module instance first.
+func (r *TextResolver) parameterText(id ParameterRefID, mi ModuleInstanceID) (string, bool) {
+	if len(id) == 0 {
+		return "", false
+	}
+
+	value, ok := "", false
+	if len(mi) > 0 {
+		ids := strings.SplitN(string(id), "_", 2)
+		if len(ids) == 2 && strings.HasPrefix(ids[0], "MD-") {
+			value, ok = r.values[ParameterRefID(string(mi)+"_"+ids[1])]
+		}
+	}
+
+	if !ok {
+		value, ok = r.values[id]
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	if p, found := r.params[id]; found && p.Type.Kind == ParameterTypeEnum {
+		if enum, found := p.Type.Enumeration(value); found {
+			return enum.Text, true
+		}
+	}
+
+	return value, true
+}
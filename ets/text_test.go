@@ -0,0 +1,45 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestTextResolver(t *testing.T) {
+	ap := &ApplicationProgram{
+		Parameters: []Parameter{
+			Parameter{ID: "P-1", Value: "Kitchen", Type: ParameterType{Kind: ParameterTypeText}},
+		},
+		ParameterRefs: []ParameterRef{
+			ParameterRef{ID: "P-1_R-1", ParameterID: "P-1"},
+		},
+		Objects: []ComObject{
+			ComObject{ID: "O-1", Text: "{{0:Channel A}}", FunctionText: "Switch", TextParameterRefID: "P-1_R-1"},
+		},
+		ObjectRefs: []ComObjectRef{
+			ComObjectRef{ID: "R-1", ComObjectID: "O-1"},
+		},
+	}
+
+	r := NewTextResolver(ap, nil)
+	if is, want := r.ComObjectText(&ap.ObjectRefs[0], &ap.Objects[0]), "Kitchen"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := r.ComObjectFunctionText(&ap.ObjectRefs[0], &ap.Objects[0]), "Switch"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	di := &DeviceInstance{
+		Parameters: []ParameterInstanceRef{
+			ParameterInstanceRef{RefID: "P-1_R-1", Value: ""},
+		},
+	}
+
+	if is, want := NewTextResolver(ap, di).ComObjectText(&ap.ObjectRefs[0], &ap.Objects[0]), "Channel A"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := r.Resolve("{{argChannel}}: {{0}}", "P-1_R-1", ""), "{{argChannel}}: Kitchen"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}
@@ -0,0 +1,184 @@
+package ets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// GroupAddressUsage lists the communication objects which use a group address by their flags.
+type GroupAddressUsage struct {
+	GroupAddress  *ResolvedGroupAddress
+	Senders       []*ResolvedComObject // transmit flag and sending connector
+	Listeners     []*ResolvedComObject // write or update flag
+	Responders    []*ResolvedComObject // read flag
+	ReadersOnInit []*ResolvedComObject // read on init flag
+}
+
+// HasSender returns true if a communication object transmits to the group address.
+func (u *GroupAddressUsage) HasSender() bool {
+	return len(u.Senders) > 0
+}
+
+// HasMultipleResponders returns true if more than one communication object answers read requests.
+func (u *GroupAddressUsage) HasMultipleResponders() bool {
+	return len(u.Responders) > 1
+}
+
+// HasUnreachableListeners returns true if communication objects listen to the group address
+// but no communication object transmits to it.
+func (u *GroupAddressUsage) HasUnreachableListeners() bool {
+	return len(u.Listeners) > 0 && !u.HasSender()
+}
+
+// GroupAddressUsageMatrix contains the usage of the group addresses of a project.
+type GroupAddressUsageMatrix struct {
+	Usages []*GroupAddressUsage
+	Style  GroupAddressStyle // notation of the group addresses in exports
+}
+
+// GroupAddressUsage returns the usage of every group address of the project
+// by the communication objects of the devices.
+func (rp *ResolvedProject) GroupAddressUsage() *GroupAddressUsageMatrix {
+	m := &GroupAddressUsageMatrix{}
+	if rp.Info != nil {
+		m.Style = rp.Info.AddressStyle
+	}
+
+	for _, ga := range rp.GroupAddresses {
+		u := &GroupAddressUsage{GroupAddress: ga}
+		for _, obj := range ga.ComObjects {
+			eff := obj.Effective()
+			if eff.TransmitFlag && obj.SendingGroupAddress == ga {
+				u.Senders = append(u.Senders, obj)
+			}
+
+			if eff.WriteFlag || eff.UpdateFlag {
+				u.Listeners = append(u.Listeners, obj)
+			}
+
+			if eff.ReadFlag {
+				u.Responders = append(u.Responders, obj)
+			}
+
+			if eff.ReadOnInitFlag {
+				u.ReadersOnInit = append(u.ReadersOnInit, obj)
+			}
+		}
+		m.Usages = append(m.Usages, u)
+	}
+
+	return m
+}
+
+// WriteCSV writes the matrix with one row for every communication object of a group address.
+// Group addresses without communication objects have one row without device and object.
+func (m *GroupAddressUsageMatrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Group Address", "Name", "Individual Address", "Device", "Number", "Object", "Send", "Listen", "Respond", "ReadOnInit"})
+
+	for _, u := range m.Usages {
+		address := u.GroupAddress.Address.Format(m.Style)
+		if len(u.GroupAddress.ComObjects) == 0 {
+			cw.Write([]string{address, u.GroupAddress.Name, "", "", "", "", "", "", "", ""})
+			continue
+		}
+
+		for _, obj := range u.GroupAddress.ComObjects {
+			o := newUsageObject(obj)
+			cw.Write([]string{
+				address,
+				u.GroupAddress.Name,
+				o.IndividualAddress,
+				o.Device,
+				strconv.FormatUint(uint64(o.Number), 10),
+				o.Text,
+				usageMark(u.Senders, obj),
+				usageMark(u.Listeners, obj),
+				usageMark(u.Responders, obj),
+				usageMark(u.ReadersOnInit, obj),
+			})
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// WriteJSON writes the matrix as indented JSON.
+func (m *GroupAddressUsageMatrix) WriteJSON(w io.Writer) error {
+	usages := make([]usageJSON, len(m.Usages))
+	for i, u := range m.Usages {
+		usages[i] = usageJSON{
+			Address:       u.GroupAddress.Address.Format(m.Style),
+			Name:          u.GroupAddress.Name,
+			Senders:       newUsageObjects(u.Senders),
+			Listeners:     newUsageObjects(u.Listeners),
+			Responders:    newUsageObjects(u.Responders),
+			ReadersOnInit: newUsageObjects(u.ReadersOnInit),
+		}
+	}
+
+	b, err := json.MarshalIndent(usages, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+
+	return err
+}
+
+type usageJSON struct {
+	Address       string        `json:"address"`
+	Name          string        `json:"name"`
+	Senders       []usageObject `json:"senders"`
+	Listeners     []usageObject `json:"listeners"`
+	Responders    []usageObject `json:"responders"`
+	ReadersOnInit []usageObject `json:"readersOnInit"`
+}
+
+// usageObject is a communication object in the exported matrix.
+type usageObject struct {
+	IndividualAddress string `json:"individualAddress,omitempty"`
+	Device            string `json:"device"`
+	Number            uint   `json:"number"`
+	Text              string `json:"text"`
+}
+
+func newUsageObject(obj *ResolvedComObject) usageObject {
+	eff := obj.Effective()
+	o := usageObject{Number: eff.Number, Text: eff.Text}
+	if dev := obj.Device; dev != nil {
+		if dev.IndividualAddress != nil {
+			o.IndividualAddress = dev.IndividualAddress.String()
+		}
+
+		o.Device = dev.Name
+		if len(o.Device) == 0 && dev.Product != nil {
+			o.Device = dev.Product.Text
+		}
+	}
+
+	return o
+}
+
+func newUsageObjects(objs []*ResolvedComObject) []usageObject {
+	res := make([]usageObject, len(objs))
+	for i, obj := range objs {
+		res[i] = newUsageObject(obj)
+	}
+
+	return res
+}
+
+func usageMark(objs []*ResolvedComObject, obj *ResolvedComObject) string {
+	for _, o := range objs {
+		if o == obj {
+			return "x"
+		}
+	}
+
+	return ""
+}
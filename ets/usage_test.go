@@ -0,0 +1,60 @@
+package ets
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGroupAddressUsage(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	projects, err := archive.ResolveProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := projects[0].GroupAddressUsage()
+	if is, want := len(m.Usages), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	u := m.Usages[0]
+	if is, want := len(u.Senders), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := u.Senders[0].Device.IndividualAddress.String(), "1.1.1"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := len(u.Listeners), 1; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if u.HasMultipleResponders() || u.HasUnreachableListeners() {
+		t.Fatal("Unexpected usage problems")
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "0/0/1,Licht,1.1.1,6131/20 Busch-Präsenzmelder Mini,10,P1: Bewegung (Master),x,,,\n") {
+		t.Fatalf("Unexpected CSV\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"address": "0/0/1"`) {
+		t.Fatalf("Unexpected JSON\n%s", buf.String())
+	}
+}
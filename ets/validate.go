@@ -0,0 +1,328 @@
+package ets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the severity of a finding.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is a problem which a rule found in a project.
+type Finding struct {
+	Severity Severity
+	RuleID   string
+	Path     string // ids of the affected element and its parents, e.g. "P-0497-0/A-2/L-3/DI-1"
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s %s: %s", f.Severity, f.RuleID, f.Path, f.Message)
+}
+
+// Rule checks a project and reports its findings to the validation context.
+type Rule struct {
+	ID       string
+	Severity Severity
+	Check    func(c *ValidationContext)
+}
+
+// IDs of the built-in rules.
+const (
+	RuleGroupAddressWithoutDatapointType = "ga-without-dpt"
+	RuleDatapointTypeSizeMismatch        = "dpt-size-mismatch"
+	RuleGroupAddressNotLinked            = "ga-not-linked"
+	RuleDeviceWithoutAddress             = "device-without-address"
+	RuleDuplicateIndividualAddress       = "duplicate-individual-address"
+	RuleGroupAddressOutsideRange         = "ga-outside-range"
+	RuleMultipleResponders               = "multiple-responders"
+	RuleTransmitWithoutLink              = "transmit-without-link"
+)
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		Rule{ID: RuleGroupAddressWithoutDatapointType, Severity: SeverityWarning, Check: checkGroupAddressDatapointTypes},
+		Rule{ID: RuleDatapointTypeSizeMismatch, Severity: SeverityError, Check: checkDatapointTypeSizes},
+		Rule{ID: RuleGroupAddressNotLinked, Severity: SeverityWarning, Check: checkGroupAddressLinks},
+		Rule{ID: RuleDeviceWithoutAddress, Severity: SeverityWarning, Check: checkDeviceAddresses},
+		Rule{ID: RuleDuplicateIndividualAddress, Severity: SeverityError, Check: checkDuplicateAddresses},
+		Rule{ID: RuleGroupAddressOutsideRange, Severity: SeverityError, Check: checkGroupAddressRanges},
+		Rule{ID: RuleMultipleResponders, Severity: SeverityWarning, Check: checkResponders},
+		Rule{ID: RuleTransmitWithoutLink, Severity: SeverityInfo, Check: checkTransmitLinks},
+	}
+}
+
+// ValidationContext is passed to the rules while validating a project.
+type ValidationContext struct {
+	Project        *ResolvedProject
+	DatapointTypes *DatapointTypeRegistry // nil if the master data is unknown
+
+	rule     *Rule
+	findings []Finding
+	ranges   map[*GroupRange]string
+}
+
+// Report adds a finding of the current rule for the element with the given path.
+func (c *ValidationContext) Report(path string, format string, args ...interface{}) {
+	c.findings = append(c.findings, Finding{
+		Severity: c.rule.Severity,
+		RuleID:   c.rule.ID,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// DevicePath returns the path of the device, e.g. "P-0497-0/A-2/L-3/DI-1".
+func (c *ValidationContext) DevicePath(dev *ResolvedDevice) string {
+	var ids []string
+	if dev.Area != nil {
+		ids = append(ids, string(dev.Area.ID))
+	}
+
+	if dev.Line != nil {
+		ids = append(ids, string(dev.Line.ID))
+	}
+
+	return joinPath(string(dev.ProjectID), append(ids, string(dev.ID))...)
+}
+
+// ComObjectPath returns the path of the communication object of a device, e.g. "P-0497-0/A-2/L-3/DI-1/O-0_R-1".
+func (c *ValidationContext) ComObjectPath(obj *ResolvedComObject) string {
+	var ids []string
+	for _, id := range []string{string(obj.Instance.ComObjectID), string(obj.Instance.ComObjectRefID)} {
+		if len(id) > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return joinPath(c.DevicePath(obj.Device), strings.Join(ids, "_"))
+}
+
+// GroupAddressPath returns the path of the group address, e.g. "P-0497-0/GR-1/GR-2/GA-1".
+func (c *ValidationContext) GroupAddressPath(ga *ResolvedGroupAddress) string {
+	if c.ranges == nil {
+		c.ranges = map[*GroupRange]string{}
+		for i := range c.Project.Installations {
+			c.addRangePaths(c.Project.Installations[i].GroupAddresses, "")
+		}
+	}
+
+	return joinPath(string(ga.ProjectID), c.ranges[ga.Range], string(ga.ID))
+}
+
+func (c *ValidationContext) addRangePaths(ranges []GroupRange, parent string) {
+	for i := range ranges {
+		gr := &ranges[i]
+		// The ids of group ranges contain the project id, e.g. "P-0497-0_GR-1".
+		id := string(gr.ID)
+		if n := strings.LastIndex(id, "_"); n >= 0 {
+			id = id[n+1:]
+		}
+
+		path := joinPath(parent, id)
+		c.ranges[gr] = path
+		c.addRangePaths(gr.SubRanges, path)
+	}
+}
+
+func joinPath(parent string, ids ...string) string {
+	var parts []string
+	for _, id := range append([]string{parent}, ids...) {
+		if len(id) > 0 {
+			parts = append(parts, id)
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Validator validates projects with a set of rules.
+type Validator struct {
+	Rules          []Rule
+	DatapointTypes *DatapointTypeRegistry // used to compare datapoint types and object sizes; may be nil
+}
+
+// NewValidator returns a validator with the default rules. The datapoint types may be nil.
+func NewValidator(dpts *DatapointTypeRegistry) *Validator {
+	return &Validator{Rules: DefaultRules(), DatapointTypes: dpts}
+}
+
+// Add adds rules, e.g. to check naming conventions.
+func (v *Validator) Add(rules ...Rule) {
+	v.Rules = append(v.Rules, rules...)
+}
+
+// Validate resolves the project with the manufacturer and hardware data and returns the findings of the rules.
+func (v *Validator) Validate(p *Project, manufacturers []*ManufacturerData, hardware []*HardwareData) []Finding {
+	return v.ValidateResolved(Resolve(p, manufacturers, hardware))
+}
+
+// ValidateResolved returns the findings of the rules for the resolved project.
+func (v *Validator) ValidateResolved(rp *ResolvedProject) []Finding {
+	c := &ValidationContext{Project: rp, DatapointTypes: v.DatapointTypes}
+	for i := range v.Rules {
+		c.rule = &v.Rules[i]
+		c.rule.Check(c)
+	}
+
+	return c.findings
+}
+
+// Validate returns the findings of the default rules for the project.
+// The datapoint type sizes are not checked if the datapoint types are nil.
+func Validate(p *Project, manufacturers []*ManufacturerData, hardware []*HardwareData, dpts *DatapointTypeRegistry) []Finding {
+	return NewValidator(dpts).Validate(p, manufacturers, hardware)
+}
+
+func checkGroupAddressDatapointTypes(c *ValidationContext) {
+	for _, ga := range c.Project.GroupAddresses {
+		if len(ga.DatapointType) == 0 {
+			c.Report(c.GroupAddressPath(ga), "Group address %s has no datapoint type", ga.Name)
+		}
+	}
+}
+
+func checkDatapointTypeSizes(c *ValidationContext) {
+	if c.DatapointTypes == nil {
+		return
+	}
+
+	for _, ga := range c.Project.GroupAddresses {
+		dpts, err := ga.DatapointTypes()
+		if err != nil {
+			continue
+		}
+
+		for _, obj := range ga.ComObjects {
+			size := obj.Effective().ObjectSize
+			for _, dpt := range dpts {
+				if ok, err := c.DatapointTypes.IsCompatible(dpt, size); err == nil && !ok {
+					c.Report(c.ComObjectPath(obj), "Object size %s doesn't match datapoint type %s of group address %s", size, dpt, ga.Name)
+				}
+			}
+		}
+	}
+}
+
+func checkGroupAddressLinks(c *ValidationContext) {
+	for _, ga := range c.Project.GroupAddresses {
+		if len(ga.ComObjects) == 0 {
+			c.Report(c.GroupAddressPath(ga), "Group address %s is not linked to any communication object", ga.Name)
+		}
+	}
+}
+
+func checkDeviceAddresses(c *ValidationContext) {
+	for _, dev := range c.Project.Devices {
+		if dev.IndividualAddress == nil {
+			c.Report(c.DevicePath(dev), "Device %s has no individual address", dev.ID)
+		}
+	}
+}
+
+func checkDuplicateAddresses(c *ValidationContext) {
+	type addressKey struct {
+		installation *Installation
+		address      IndividualAddress
+	}
+
+	devices := map[addressKey]*ResolvedDevice{}
+	for _, dev := range c.Project.Devices {
+		if dev.IndividualAddress == nil {
+			continue
+		}
+
+		key := addressKey{dev.Installation, *dev.IndividualAddress}
+		if other, ok := devices[key]; ok {
+			c.Report(c.DevicePath(dev), "Individual address %s is already used by device %s", dev.IndividualAddress, other.ID)
+			continue
+		}
+		devices[key] = dev
+	}
+}
+
+func checkGroupAddressRanges(c *ValidationContext) {
+	for _, ga := range c.Project.GroupAddresses {
+		gr := ga.Range
+		if gr == nil || gr.RangeStart > gr.RangeEnd {
+			continue
+		}
+
+		if !gr.Contains(ga.Address) {
+			c.Report(c.GroupAddressPath(ga), "Group address %s is outside of group range %s", ga.Name, gr.Name)
+		}
+	}
+}
+
+func checkResponders(c *ValidationContext) {
+	for _, u := range c.Project.GroupAddressUsage().Usages {
+		if u.HasMultipleResponders() {
+			c.Report(c.GroupAddressPath(u.GroupAddress), "Group address %s has %d communication objects with read flag", u.GroupAddress.Name, len(u.Responders))
+		}
+	}
+}
+
+func checkTransmitLinks(c *ValidationContext) {
+	indexes := map[*ApplicationProgram]*comObjectIndex{}
+	for _, dev := range c.Project.Devices {
+		if dev.Program == nil {
+			continue
+		}
+
+		idx, ok := indexes[dev.Program]
+		if !ok {
+			idx = newComObjectIndex(dev.Program)
+			indexes[dev.Program] = idx
+		}
+
+		// The device only contains instances of objects which are linked or changed.
+		for _, ref := range dev.ActiveObjectRefs() {
+			ref := ref
+			obj := deviceComObject(dev, &ref)
+			if obj == nil {
+				obj = &ResolvedComObject{
+					Instance: &ComObjectInstanceRef{ComObjectRefID: ref.ID, ComObjectID: ref.ComObjectID, ModuleInstanceID: ref.ModuleInstanceID},
+					Ref:      &ref,
+					Object:   idx.objects[comObjectKey{ref.ComObjectID, ref.ModuleInstanceID}],
+					Device:   dev,
+				}
+			}
+
+			if obj.Effective().TransmitFlag && len(obj.Instance.Connectors) == 0 {
+				c.Report(c.ComObjectPath(obj), "Communication object with transmit flag is not linked to a group address")
+			}
+		}
+	}
+}
+
+// deviceComObject returns the communication object of the device for the reference,
+// or nil if the device has no instance of the reference.
+func deviceComObject(dev *ResolvedDevice, ref *ComObjectRef) *ResolvedComObject {
+	for _, obj := range dev.ComObjects {
+		if r := obj.Ref; r != nil && r.ID == ref.ID && r.ComObjectID == ref.ComObjectID && r.ModuleInstanceID == ref.ModuleInstanceID {
+			return obj
+		}
+	}
+
+	return nil
+}
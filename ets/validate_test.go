@@ -0,0 +1,115 @@
+package ets
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	archive, err := OpenExportArchive("Testproject.knxproj", "testabcdefg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	dpts, err := archive.DatapointTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := archive.ResolveProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects with transmit flag which are not linked are reported
+	// even if the device contains no instance of them.
+	var unlinked []string
+	for _, f := range NewValidator(dpts).ValidateResolved(projects[0]) {
+		if f.RuleID != RuleTransmitWithoutLink {
+			t.Fatalf("Unexpected finding %v", f)
+		}
+		unlinked = append(unlinked, f.Path)
+	}
+
+	if is, want := len(unlinked), 14; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := unlinked[0], "P-0497-0/A-2/L-3/DI-1/O-33_R-91"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	var manufacturers []*ManufacturerData
+	for _, mf := range archive.ManufacturerFiles {
+		md, err := mf.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		manufacturers = append(manufacturers, md)
+	}
+
+	var hardware []*HardwareData
+	for _, hf := range archive.HardwareFiles {
+		hd, err := hf.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hardware = append(hardware, hd)
+	}
+
+	p, err := archive.ProjectFiles[0].InstallationFiles[0].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inst := &p.Installations[0]
+	gr := &inst.GroupAddresses[0].SubRanges[0]
+	gr.Addresses[0].DatapointType = "DPST-9-1"
+	gr.Addresses = append(gr.Addresses, GroupAddress{ID: "GA-2", ProjectID: "P-0497-0", Name: "Outside", Address: 300})
+
+	line := &inst.Topology[1].Lines[1]
+	addr := *line.Devices[0].IndividualAddress
+	line.Devices[1].IndividualAddress = &addr
+
+	findings := map[string]string{}
+	for _, f := range Validate(p, manufacturers, hardware, dpts) {
+		findings[f.RuleID] = f.Path
+	}
+
+	tests := map[string]string{
+		RuleGroupAddressOutsideRange:         "P-0497-0/GR-1/GR-2/GA-2",
+		RuleGroupAddressWithoutDatapointType: "P-0497-0/GR-1/GR-2/GA-2",
+		RuleGroupAddressNotLinked:            "P-0497-0/GR-1/GR-2/GA-2",
+		RuleDuplicateIndividualAddress:       "P-0497-0/A-2/L-3/DI-2",
+		RuleDatapointTypeSizeMismatch:        "P-0497-0/A-2/L-3/DI-2/O-0_R-10000",
+	}
+
+	for rule, path := range tests {
+		if is, want := findings[rule], path; is != want {
+			t.Fatalf("%s: %v != %v", rule, is, want)
+		}
+	}
+
+	v := NewValidator(nil)
+	v.Add(Rule{ID: "ga-name", Severity: SeverityInfo, Check: func(c *ValidationContext) {
+		for _, ga := range c.Project.GroupAddresses {
+			if len(ga.Name) < 6 {
+				c.Report(c.GroupAddressPath(ga), "Name %s is too short", ga.Name)
+			}
+		}
+	}})
+
+	findings = map[string]string{}
+	for _, f := range v.Validate(p, manufacturers, hardware) {
+		findings[f.RuleID] = f.Path
+	}
+
+	// The datapoint type sizes are not checked without datapoint types.
+	if is, want := findings[RuleDatapointTypeSizeMismatch], ""; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+
+	if is, want := findings["ga-name"], "P-0497-0/GR-1/GR-2/GA-1"; is != want {
+		t.Fatalf("%v != %v", is, want)
+	}
+}